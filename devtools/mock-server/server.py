@@ -39,10 +39,10 @@ sites = [
 # All keyed by site_id
 zones: dict[str, list[dict]] = {
     "site-default": [
-        {"id": "zone-lan", "name": "Internal", "networkIds": ["net-1"]},
-        {"id": "zone-wan", "name": "External", "networkIds": ["net-2"]},
-        {"id": "zone-guest", "name": "Hotspot", "networkIds": ["net-3"]},
-        {"id": "zone-iot", "name": "IoT", "networkIds": ["net-4"]},
+        {"id": "zone-lan", "name": "Internal", "networkIds": ["net-1"], "metadata": {"origin": "SYSTEM_DEFINED"}},
+        {"id": "zone-wan", "name": "External", "networkIds": ["net-2"], "metadata": {"origin": "SYSTEM_DEFINED"}},
+        {"id": "zone-guest", "name": "Hotspot", "networkIds": ["net-3"], "metadata": {"origin": "SYSTEM_DEFINED"}},
+        {"id": "zone-iot", "name": "IoT", "networkIds": ["net-4"], "metadata": {"origin": "USER_DEFINED"}},
     ],
 }
 
@@ -56,7 +56,33 @@ networks: dict[str, list[dict]] = {
 }
 
 fw_policies: dict[str, list[dict]] = {"site-default": []}
+fw_ordering: dict[str, dict] = {}  # keyed by "site/srcZone/dstZone"
 dns_policies: dict[str, list[dict]] = {"site-default": []}
+traffic_lists: dict[str, list[dict]] = {"site-default": []}
+
+# VPN servers and tunnels are read-only; they are configured in the UI.
+vpn_servers: dict[str, list[dict]] = {
+    "site-default": [
+        {"id": "vpn-1", "name": "Remote Access", "type": "WIREGUARD", "enabled": True},
+    ],
+}
+vpn_tunnels: dict[str, list[dict]] = {
+    "site-default": [
+        {"id": "s2s-1", "name": "Branch Office", "type": "IPSEC"},
+    ],
+}
+
+# The DPI catalog is global to the controller, not per site.
+dpi_applications = [
+    {"id": 65539, "name": "BitTorrent"},
+    {"id": 262256, "name": "Facebook"},
+    {"id": 327723, "name": "Netflix"},
+]
+dpi_categories = [
+    {"id": 4, "name": "Peer-to-Peer"},
+    {"id": 5, "name": "Streaming Media"},
+    {"id": 24, "name": "Social Media"},
+]
 
 clients: dict[str, list[dict]] = {
     "site-default": [
@@ -111,6 +137,7 @@ def build_snapshot() -> dict:
             "networks": {k: v[:] for k, v in networks.items()},
             "fw_policies": {k: v[:] for k, v in fw_policies.items()},
             "dns_policies": {k: v[:] for k, v in dns_policies.items()},
+            "traffic_lists": {k: v[:] for k, v in traffic_lists.items()},
             "clients": {k: v[:] for k, v in clients.items()},
             "events": event_log[-50:],
             "stats": {
@@ -262,6 +289,32 @@ def list_sites():
 
 
 # Firewall Zones
+@app.route("/v1/sites/<site_id>/vpn/servers", methods=["GET"])
+def list_vpn_servers(site_id):
+    log_event("LIST", "vpn_server", "*", f"site={site_id}")
+    with lock:
+        return make_list_response(vpn_servers.get(site_id, []))
+
+
+@app.route("/v1/sites/<site_id>/vpn/site-to-site-tunnels", methods=["GET"])
+def list_vpn_tunnels(site_id):
+    log_event("LIST", "vpn_tunnel", "*", f"site={site_id}")
+    with lock:
+        return make_list_response(vpn_tunnels.get(site_id, []))
+
+
+@app.route("/v1/dpi/applications", methods=["GET"])
+def list_dpi_applications():
+    log_event("LIST", "dpi_application", "*")
+    return make_list_response(dpi_applications)
+
+
+@app.route("/v1/dpi/categories", methods=["GET"])
+def list_dpi_categories():
+    log_event("LIST", "dpi_category", "*")
+    return make_list_response(dpi_categories)
+
+
 @app.route("/v1/sites/<site_id>/firewall/zones", methods=["GET"])
 def list_zones(site_id):
     log_event("LIST", "zone", "*", f"site={site_id}")
@@ -274,12 +327,57 @@ def list_zones(site_id):
 def create_zone(site_id):
     data = request.get_json()
     data["id"] = f"zone-{uuid.uuid4().hex[:8]}"
+    data["metadata"] = {"origin": "USER_DEFINED"}
     with lock:
         zones.setdefault(site_id, []).append(data)
     log_event("CREATE", "zone", data["id"], data.get("name", ""))
     return jsonify(data), 201
 
 
+def _find_zone(site_id, zone_id):
+    for z in zones.get(site_id, []):
+        if z["id"] == zone_id:
+            return z
+    return None
+
+
+@app.route("/v1/sites/<site_id>/firewall/zones/<zone_id>", methods=["GET"])
+def get_zone(site_id, zone_id):
+    with lock:
+        z = _find_zone(site_id, zone_id)
+        if z is None:
+            return error_response(404, "not_found", f"Zone '{zone_id}' not found")
+        return jsonify(z)
+
+
+@app.route("/v1/sites/<site_id>/firewall/zones/<zone_id>", methods=["PUT"])
+def update_zone(site_id, zone_id):
+    data = request.get_json()
+    with lock:
+        z = _find_zone(site_id, zone_id)
+        if z is None:
+            return error_response(404, "not_found", f"Zone '{zone_id}' not found")
+        if z.get("metadata", {}).get("origin") == "SYSTEM_DEFINED":
+            return error_response(400, "bad_request", f"Zone '{z['name']}' is system defined")
+        z["name"] = data.get("name", z["name"])
+        z["networkIds"] = data.get("networkIds", [])
+    log_event("UPDATE", "zone", zone_id, z["name"])
+    return jsonify(z)
+
+
+@app.route("/v1/sites/<site_id>/firewall/zones/<zone_id>", methods=["DELETE"])
+def delete_zone(site_id, zone_id):
+    with lock:
+        z = _find_zone(site_id, zone_id)
+        if z is None:
+            return error_response(404, "not_found", f"Zone '{zone_id}' not found")
+        if z.get("metadata", {}).get("origin") == "SYSTEM_DEFINED":
+            return error_response(400, "bad_request", f"Zone '{z['name']}' is system defined")
+        zones[site_id].remove(z)
+    log_event("DELETE", "zone", zone_id)
+    return "", 204
+
+
 # Networks
 @app.route("/v1/sites/<site_id>/networks", methods=["GET"])
 def list_networks(site_id):
@@ -289,6 +387,140 @@ def list_networks(site_id):
         return make_list_response(items)
 
 
+def _find_network(site_id, network_id):
+    for n in networks.get(site_id, []):
+        if n["id"] == network_id:
+            return n
+    return None
+
+
+def _default_zone_id(site_id):
+    for z in zones.get(site_id, []):
+        if z["name"] == "Internal":
+            return z["id"]
+    return ""
+
+
+@app.route("/v1/sites/<site_id>/networks", methods=["POST"])
+def create_network(site_id):
+    data = request.get_json()
+    with lock:
+        if any(n.get("vlanId") == data.get("vlanId") for n in networks.get(site_id, [])):
+            return error_response(400, "bad_request", f"VLAN {data.get('vlanId')} is already in use")
+        data["id"] = f"net-{uuid.uuid4().hex[:8]}"
+        data.setdefault("enabled", True)
+        # New gateway networks land in the Internal zone, like on a real console.
+        data["zoneId"] = _default_zone_id(site_id)
+        networks.setdefault(site_id, []).append(data)
+        for z in zones.get(site_id, []):
+            if z["id"] == data["zoneId"]:
+                z["networkIds"].append(data["id"])
+    log_event("CREATE", "network", data["id"], data.get("name", ""))
+    return jsonify(data), 201
+
+
+@app.route("/v1/sites/<site_id>/networks/<network_id>", methods=["GET"])
+def get_network(site_id, network_id):
+    with lock:
+        n = _find_network(site_id, network_id)
+        if n is None:
+            return error_response(404, "not_found", f"Network '{network_id}' not found")
+        return jsonify(n)
+
+
+@app.route("/v1/sites/<site_id>/networks/<network_id>", methods=["PUT"])
+def update_network(site_id, network_id):
+    data = request.get_json()
+    with lock:
+        n = _find_network(site_id, network_id)
+        if n is None:
+            return error_response(404, "not_found", f"Network '{network_id}' not found")
+        zone_id = n.get("zoneId", "")
+        n.clear()
+        n.update(data)
+        n["id"] = network_id
+        n["zoneId"] = zone_id
+    log_event("UPDATE", "network", network_id, n.get("name", ""))
+    return jsonify(n)
+
+
+@app.route("/v1/sites/<site_id>/networks/<network_id>", methods=["DELETE"])
+def delete_network(site_id, network_id):
+    with lock:
+        n = _find_network(site_id, network_id)
+        if n is None:
+            return error_response(404, "not_found", f"Network '{network_id}' not found")
+        networks[site_id].remove(n)
+        for z in zones.get(site_id, []):
+            if network_id in z.get("networkIds", []):
+                z["networkIds"].remove(network_id)
+    log_event("DELETE", "network", network_id)
+    return "", 204
+
+
+# Traffic Matching Lists
+@app.route("/v1/sites/<site_id>/traffic-matching-lists", methods=["GET"])
+def list_traffic_lists(site_id):
+    log_event("LIST", "traffic_list", "*", f"site={site_id}")
+    with lock:
+        items = traffic_lists.get(site_id, [])
+        return make_list_response(items)
+
+
+def _find_traffic_list(site_id, list_id):
+    for tl in traffic_lists.get(site_id, []):
+        if tl["id"] == list_id:
+            return tl
+    return None
+
+
+@app.route("/v1/sites/<site_id>/traffic-matching-lists", methods=["POST"])
+def create_traffic_list(site_id):
+    data = request.get_json()
+    if data.get("type") not in ("PORTS", "IPV4_ADDRESSES", "IPV6_ADDRESSES"):
+        return error_response(400, "bad_request", f"Invalid traffic matching list type '{data.get('type')}'")
+    with lock:
+        data["id"] = f"tml-{uuid.uuid4().hex[:8]}"
+        traffic_lists.setdefault(site_id, []).append(data)
+    log_event("CREATE", "traffic_list", data["id"], data.get("name", ""))
+    return jsonify(data), 201
+
+
+@app.route("/v1/sites/<site_id>/traffic-matching-lists/<list_id>", methods=["GET"])
+def get_traffic_list(site_id, list_id):
+    with lock:
+        tl = _find_traffic_list(site_id, list_id)
+        if tl is None:
+            return error_response(404, "not_found", f"Traffic matching list '{list_id}' not found")
+        return jsonify(tl)
+
+
+@app.route("/v1/sites/<site_id>/traffic-matching-lists/<list_id>", methods=["PUT"])
+def update_traffic_list(site_id, list_id):
+    data = request.get_json()
+    with lock:
+        tl = _find_traffic_list(site_id, list_id)
+        if tl is None:
+            return error_response(404, "not_found", f"Traffic matching list '{list_id}' not found")
+        if data.get("type", tl["type"]) != tl["type"]:
+            return error_response(400, "bad_request", "The type of a traffic matching list cannot be changed")
+        tl["name"] = data.get("name", tl["name"])
+        tl["items"] = data.get("items", [])
+    log_event("UPDATE", "traffic_list", list_id, tl["name"])
+    return jsonify(tl)
+
+
+@app.route("/v1/sites/<site_id>/traffic-matching-lists/<list_id>", methods=["DELETE"])
+def delete_traffic_list(site_id, list_id):
+    with lock:
+        tl = _find_traffic_list(site_id, list_id)
+        if tl is None:
+            return error_response(404, "not_found", f"Traffic matching list '{list_id}' not found")
+        traffic_lists[site_id].remove(tl)
+    log_event("DELETE", "traffic_list", list_id)
+    return "", 204
+
+
 # Firewall Policies
 @app.route("/v1/sites/<site_id>/firewall/policies", methods=["GET"])
 def list_fw_policies(site_id):
@@ -460,17 +692,59 @@ def update_client(site_id, client_id):
     return error_response(404, "not_found", f"Client '{client_id}' not found")
 
 
-# Firewall Policy Ordering (stub)
-@app.route("/v1/sites/<site_id>/firewall/policy/ordering", methods=["GET"])
-def get_fw_ordering(site_id):
-    with lock:
-        ids = [p["id"] for p in fw_policies.get(site_id, [])]
-    return jsonify({"data": ids})
+# Firewall Policy Ordering (per source/destination zone pair)
+def _ordering_key(site_id):
+    src = request.args.get("sourceFirewallZoneId")
+    dst = request.args.get("destinationFirewallZoneId")
+    if not src or not dst:
+        return None, None, None
+    return f"{site_id}/{src}/{dst}", src, dst
+
 
+def _pair_policy_ids(site_id, src, dst):
+    return [
+        p["id"] for p in fw_policies.get(site_id, [])
+        if p.get("source", {}).get("zoneId") == src and p.get("destination", {}).get("zoneId") == dst
+    ]
 
-@app.route("/v1/sites/<site_id>/firewall/policy/ordering", methods=["PUT"])
+
+@app.route("/v1/sites/<site_id>/firewall/policies/ordering", methods=["GET"])
+def get_fw_ordering(site_id):
+    key, src, dst = _ordering_key(site_id)
+    if key is None:
+        return error_response(400, "bad_request", "sourceFirewallZoneId and destinationFirewallZoneId are required")
+    with lock:
+        pair_ids = _pair_policy_ids(site_id, src, dst)
+        stored = fw_ordering.get(key)
+        if stored is None:
+            ordering = {"beforeSystemDefined": pair_ids, "afterSystemDefined": []}
+        else:
+            # Drop deleted policies and append new ones in creation order.
+            listed = stored["beforeSystemDefined"] + stored["afterSystemDefined"]
+            ordering = {
+                "beforeSystemDefined": [i for i in stored["beforeSystemDefined"] if i in pair_ids]
+                + [i for i in pair_ids if i not in listed],
+                "afterSystemDefined": [i for i in stored["afterSystemDefined"] if i in pair_ids],
+            }
+    return jsonify({"orderedFirewallPolicyIds": ordering})
+
+
+@app.route("/v1/sites/<site_id>/firewall/policies/ordering", methods=["PUT"])
 def set_fw_ordering(site_id):
-    return jsonify({"data": request.get_json()})
+    key, src, dst = _ordering_key(site_id)
+    if key is None:
+        return error_response(400, "bad_request", "sourceFirewallZoneId and destinationFirewallZoneId are required")
+    ordering = (request.get_json(silent=True) or {}).get("orderedFirewallPolicyIds") or {}
+    before = ordering.get("beforeSystemDefined") or []
+    after = ordering.get("afterSystemDefined") or []
+    with lock:
+        pair_ids = _pair_policy_ids(site_id, src, dst)
+        listed = before + after
+        if len(set(listed)) != len(listed) or set(listed) != set(pair_ids):
+            return error_response(400, "bad_request", "ordering must list every policy of the zone pair exactly once")
+        fw_ordering[key] = {"beforeSystemDefined": before, "afterSystemDefined": after}
+    log_event("UPDATE", "fw_ordering", key, f"{len(before)} before, {len(after)} after")
+    return jsonify({"orderedFirewallPolicyIds": fw_ordering[key]})
 
 
 # ---------------------------------------------------------------------------
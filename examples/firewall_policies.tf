@@ -73,6 +73,11 @@ resource "unifi_fw" "allow_admin_access" {
   }
   ip_protocol_scope {
     ip_version = "IPV4"
+    protocol_filter {
+      type           = "PROTOCOL"
+      match_opposite = false
+      protocol       = "tcp"
+    }
   }
   logging_enabled = true
 }
@@ -105,6 +110,11 @@ resource "unifi_fw" "allow_app_ports" {
   }
   ip_protocol_scope {
     ip_version = "IPV4"
+    protocol_filter {
+      type           = "PROTOCOL"
+      match_opposite = false
+      protocol       = "tcp"
+    }
   }
   logging_enabled = false
 }
@@ -344,6 +354,11 @@ resource "unifi_fw" "block_specific_source_port" {
   }
   ip_protocol_scope {
     ip_version = "IPV4"
+    protocol_filter {
+      type           = "PROTOCOL"
+      match_opposite = false
+      protocol       = "tcp"
+    }
   }
   logging_enabled = true
 }
@@ -380,6 +395,11 @@ resource "unifi_fw" "limit_iot_server_access" {
   }
   ip_protocol_scope {
     ip_version = "IPV4"
+    protocol_filter {
+      type           = "PROTOCOL"
+      match_opposite = false
+      protocol       = "tcp"
+    }
   }
   logging_enabled = true
 }
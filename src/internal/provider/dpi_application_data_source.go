@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var (
+	_ datasource.DataSource              = &DPIApplicationDataSource{}
+	_ datasource.DataSourceWithConfigure = &DPIApplicationDataSource{}
+)
+
+// DPIApplicationDataSource resolves an application or application category
+// name from the controller's DPI catalog to the ID used by a unifi_fw
+// application_filter.
+type DPIApplicationDataSource struct {
+	client *unifi.Client
+}
+
+type DPIApplicationDataSourceModel struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+func NewDPIApplicationDataSource() datasource.DataSource {
+	return &DPIApplicationDataSource{}
+}
+
+func (d *DPIApplicationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dpi_application"
+}
+
+func (d *DPIApplicationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a DPI application or application category by name.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name as shown in the UniFi UI, e.g. `BitTorrent` or `Social Media`. Matched case-insensitively.",
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "What to look up: `APPLICATION` (the default) or `CATEGORY`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("APPLICATION", "CATEGORY"),
+				},
+			},
+			"id": schema.Int64Attribute{
+				Computed: true,
+				MarkdownDescription: "The application ID, for `application_filter.application_ids`, or the category ID, " +
+					"for `application_filter.category_ids`.",
+			},
+		},
+	}
+}
+
+func (d *DPIApplicationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*unifi.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *unifi.Client, got %T", req.ProviderData))
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DPIApplicationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DPIApplicationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var entries []dpiEntry
+	kind := "application"
+	if data.Type.ValueString() == "CATEGORY" {
+		kind = "category"
+		categories, err := d.client.ListDPICategories(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing DPI categories", err.Error())
+			return
+		}
+		for _, c := range categories {
+			entries = append(entries, dpiEntry{id: c.ID, name: c.Name})
+		}
+	} else {
+		apps, err := d.client.ListDPIApplications(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing DPI applications", err.Error())
+			return
+		}
+		for _, a := range apps {
+			entries = append(entries, dpiEntry{id: a.ID, name: a.Name})
+		}
+	}
+
+	id, err := findDPIEntry(entries, kind, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("DPI "+kind+" not found", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(id))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// dpiEntry is an application or category from the DPI catalog.
+type dpiEntry struct {
+	id   int
+	name string
+}
+
+// findDPIEntry returns the ID of the entry called name. An exact match wins
+// over case-insensitive ones; several case-insensitive matches are an error.
+func findDPIEntry(entries []dpiEntry, kind, name string) (int, error) {
+	var folded []dpiEntry
+	for _, e := range entries {
+		if e.name == name {
+			return e.id, nil
+		}
+		if strings.EqualFold(e.name, name) {
+			folded = append(folded, e)
+		}
+	}
+
+	switch len(folded) {
+	case 0:
+		return 0, fmt.Errorf("no DPI %s named %q", kind, name)
+	case 1:
+		return folded[0].id, nil
+	default:
+		return 0, fmt.Errorf("more than one DPI %s matches %q (%q and %q); use the exact name", kind, name, folded[0].name, folded[1].name)
+	}
+}
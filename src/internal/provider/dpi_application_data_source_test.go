@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindDPIEntry(t *testing.T) {
+	entries := []dpiEntry{
+		{id: 1, name: "BitTorrent"},
+		{id: 2, name: "Facebook"},
+		{id: 3, name: "facebook"},
+		{id: 4, name: "Netflix"},
+	}
+
+	tests := []struct {
+		name   string
+		wantID int
+	}{
+		{"BitTorrent", 1},
+		{"bittorrent", 1},
+		{"facebook", 3},
+		{"Facebook", 2},
+		{"NETFLIX", 4},
+	}
+	for _, tt := range tests {
+		id, err := findDPIEntry(entries, "application", tt.name)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if id != tt.wantID {
+			t.Errorf("%q: expected ID %d, got %d", tt.name, tt.wantID, id)
+		}
+	}
+}
+
+func TestFindDPIEntry_Errors(t *testing.T) {
+	entries := []dpiEntry{
+		{id: 2, name: "Facebook"},
+		{id: 3, name: "facebook"},
+	}
+
+	if _, err := findDPIEntry(entries, "category", "Gaming"); err == nil || !strings.Contains(err.Error(), `category named "Gaming"`) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if _, err := findDPIEntry(entries, "application", "FACEBOOK"); err == nil || !strings.Contains(err.Error(), "more than one") {
+		t.Errorf("expected ambiguity error, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package firewall
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// addressEntry is an IP address, subnet or address range as written in
+// configuration: "10.0.0.1", "10.0.0.0/8" or "10.0.0.10-10.0.0.50", and the
+// IPv6 equivalents.
+type addressEntry struct {
+	Type  string // IP_ADDRESS, SUBNET or IP_ADDRESS_RANGE
+	Value string // IP_ADDRESS and SUBNET
+	Start string // IP_ADDRESS_RANGE
+	Stop  string // IP_ADDRESS_RANGE
+
+	addr netip.Addr // the (first) address, for family checks
+}
+
+// parseAddressEntry classifies entry. Host routes (/32, /128) are treated as
+// plain addresses, and all addresses are put in canonical form so that
+// "2001:DB8::1" and "2001:db8::1" compare equal.
+func parseAddressEntry(entry string) (addressEntry, error) {
+	if start, stop, ok := strings.Cut(entry, "-"); ok {
+		from, errFrom := netip.ParseAddr(start)
+		to, errTo := netip.ParseAddr(stop)
+		if errFrom != nil || errTo != nil {
+			return addressEntry{}, fmt.Errorf("%q is not a valid address range; use <first>-<last>", entry)
+		}
+		if from.Is4() != to.Is4() {
+			return addressEntry{}, fmt.Errorf("%q mixes IPv4 and IPv6 addresses", entry)
+		}
+		if !from.Less(to) {
+			return addressEntry{}, fmt.Errorf("%q is not a valid address range; the first address must be lower than the last", entry)
+		}
+		return addressEntry{Type: "IP_ADDRESS_RANGE", Start: from.String(), Stop: to.String(), addr: from}, nil
+	}
+
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return addressEntry{}, fmt.Errorf("%q is not a valid CIDR subnet", entry)
+		}
+		if prefix.IsSingleIP() {
+			return addressEntry{Type: "IP_ADDRESS", Value: prefix.Addr().String(), addr: prefix.Addr()}, nil
+		}
+		return addressEntry{Type: "SUBNET", Value: prefix.String(), addr: prefix.Addr()}, nil
+	}
+
+	addr, err := netip.ParseAddr(entry)
+	if err != nil {
+		return addressEntry{}, fmt.Errorf("%q is not a valid IP address, subnet or range", entry)
+	}
+	return addressEntry{Type: "IP_ADDRESS", Value: addr.String(), addr: addr}, nil
+}
+
+// String returns the canonical configuration form of the entry.
+func (e addressEntry) String() string {
+	if e.Type == "IP_ADDRESS_RANGE" {
+		return e.Start + "-" + e.Stop
+	}
+	return e.Value
+}
+
+func (e addressEntry) Is4() bool {
+	return e.addr.Is4()
+}
+
+// canonicalAddressEntry returns the canonical form of entry, or entry itself
+// when it does not parse.
+func canonicalAddressEntry(entry string) string {
+	if e, err := parseAddressEntry(entry); err == nil {
+		return e.String()
+	}
+	return entry
+}
+
+// preferConfiguredSpelling returns observed with every entry that is
+// equivalent to one in configured under canonical replaced by the configured
+// spelling, so that "10.0.0.1/32" in configuration does not drift against the
+// "10.0.0.1" the controller returns.
+func preferConfiguredSpelling(observed, configured []string, canonical func(string) string) []string {
+	spelling := make(map[string]string, len(configured))
+	for _, c := range configured {
+		spelling[canonical(c)] = c
+	}
+
+	out := make([]string, len(observed))
+	for i, o := range observed {
+		if c, ok := spelling[canonical(o)]; ok {
+			out[i] = c
+		} else {
+			out[i] = o
+		}
+	}
+	return out
+}
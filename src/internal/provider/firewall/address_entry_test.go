@@ -0,0 +1,64 @@
+package firewall
+
+import (
+	"testing"
+)
+
+func TestParseAddressEntry(t *testing.T) {
+	tests := []struct {
+		entry    string
+		wantType string
+		want     string
+		want4    bool
+	}{
+		{"10.0.0.1", "IP_ADDRESS", "10.0.0.1", true},
+		{"10.0.0.1/32", "IP_ADDRESS", "10.0.0.1", true},
+		{"10.0.0.0/8", "SUBNET", "10.0.0.0/8", true},
+		{"10.0.0.10-10.0.0.50", "IP_ADDRESS_RANGE", "10.0.0.10-10.0.0.50", true},
+		{"2001:DB8::1", "IP_ADDRESS", "2001:db8::1", false},
+		{"2001:db8::1/128", "IP_ADDRESS", "2001:db8::1", false},
+		{"2001:db8::/64", "SUBNET", "2001:db8::/64", false},
+		{"fd00::1-fd00::ff", "IP_ADDRESS_RANGE", "fd00::1-fd00::ff", false},
+	}
+	for _, tt := range tests {
+		got, err := parseAddressEntry(tt.entry)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.entry, err)
+			continue
+		}
+		if got.Type != tt.wantType || got.String() != tt.want || got.Is4() != tt.want4 {
+			t.Errorf("%q: got %s %q (IPv4=%v), want %s %q (IPv4=%v)",
+				tt.entry, got.Type, got.String(), got.Is4(), tt.wantType, tt.want, tt.want4)
+		}
+	}
+}
+
+func TestParseAddressEntry_Invalid(t *testing.T) {
+	for _, entry := range []string{
+		"",
+		"10.0.0",
+		"10.0.0.0/33",
+		"10.0.0.50-10.0.0.10",
+		"10.0.0.1-10.0.0.1",
+		"10.0.0.1-fd00::1",
+		"10.0.0.1-",
+		"example.com",
+	} {
+		if _, err := parseAddressEntry(entry); err == nil {
+			t.Errorf("%q: expected an error", entry)
+		}
+	}
+}
+
+func TestPreferConfiguredSpelling(t *testing.T) {
+	observed := []string{"10.0.0.1", "2001:db8::1", "192.168.1.0/24"}
+	configured := []string{"10.0.0.1/32", "2001:DB8::1"}
+
+	got := preferConfiguredSpelling(observed, configured, canonicalAddressEntry)
+	want := []string{"10.0.0.1/32", "2001:DB8::1", "192.168.1.0/24"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("item %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
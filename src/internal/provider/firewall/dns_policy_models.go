@@ -7,6 +7,7 @@ import (
 type DNSPolicyResourceModel struct {
 	ID      types.String `tfsdk:"id"`
 	SiteID  types.String `tfsdk:"site_id"`
+	Site    types.String `tfsdk:"site"`
 	Type    types.String `tfsdk:"type"`
 	Domain  types.String `tfsdk:"domain"`
 	Enabled types.Bool   `tfsdk:"enabled"`
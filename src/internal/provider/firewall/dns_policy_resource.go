@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
 	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
 )
 
@@ -45,11 +49,17 @@ func (r *DNSPolicyResource) Schema(ctx context.Context, req resource.SchemaReque
 			"site_id": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "The ID of the site.",
+				MarkdownDescription: "The ID of the site the policy lives in.",
+				DeprecationMessage:  "Use site instead. When set, site_id is used as the site.",
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("site")),
+				},
 			},
+			"site": dnsSiteAttribute(func() *unifi.Client { return r.client }),
 			"type": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The type of DNS policy (e.g., A_RECORD, AAAA_RECORD, CNAME_RECORD, MX_RECORD, TXT_RECORD, SRV_RECORD, FORWARD_DOMAIN).",
@@ -131,10 +141,51 @@ func (r *DNSPolicyResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
-// effectiveSiteID returns the site ID to use for DNS API calls. Always uses
-// the provider-level discovered site ID to avoid stale values from state.
-func (r *DNSPolicyResource) effectiveSiteID(_ types.String) string {
-	return r.client.SiteID
+// siteContext scopes ctx to the site of m and returns the site's ID for the
+// DNS API calls. The deprecated site_id stands in for site when only it is
+// set; otherwise it records the ID that site resolved to.
+func (r *DNSPolicyResource) siteContext(ctx context.Context, m *DNSPolicyResourceModel, diags *diag.Diagnostics) (context.Context, string) {
+	if (m.Site.IsNull() || m.Site.IsUnknown()) && !m.SiteID.IsNull() && !m.SiteID.IsUnknown() {
+		m.Site = m.SiteID
+	}
+	ctx = sitescope.Context(ctx, r.client, &m.Site, diags)
+
+	siteID := r.client.ContextSiteID(ctx)
+	if m.SiteID.IsNull() || m.SiteID.IsUnknown() {
+		m.SiteID = types.StringValue(siteID)
+	}
+	return ctx, siteID
+}
+
+// dnsSiteAttribute is the site attribute with the deprecated site_id standing
+// in for site when only site_id is configured.
+func dnsSiteAttribute(client func() *unifi.Client) schema.StringAttribute {
+	attr := sitescope.ResourceAttribute(client)
+	attr.PlanModifiers = append(attr.PlanModifiers, siteFromSiteID{})
+	return attr
+}
+
+// siteFromSiteID plans the configured site_id as the site when site itself is
+// not configured.
+type siteFromSiteID struct{}
+
+func (m siteFromSiteID) Description(ctx context.Context) string {
+	return "Uses site_id as the site when only site_id is configured."
+}
+
+func (m siteFromSiteID) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m siteFromSiteID) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.Plan.Raw.IsNull() || !req.ConfigValue.IsNull() {
+		return
+	}
+	var siteID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("site_id"), &siteID)...)
+	if !siteID.IsNull() {
+		resp.PlanValue = siteID
+	}
 }
 
 func (r *DNSPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -192,16 +243,18 @@ func (r *DNSPolicyResource) Create(ctx context.Context, req resource.CreateReque
 		policy.TTL = int(plan.TTL.ValueInt64())
 	}
 
-	siteID := r.effectiveSiteID(plan.SiteID)
+	ctx, siteID := r.siteContext(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	createdPolicy, err := r.client.CreateDNSPolicy(siteID, policy)
+	createdPolicy, err := r.client.CreateDNSPolicy(ctx, siteID, policy)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating DNS policy", err.Error())
 		return
 	}
 
 	plan.ID = types.StringValue(createdPolicy.ID)
-	plan.SiteID = types.StringValue(siteID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -213,10 +266,18 @@ func (r *DNSPolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	siteID := r.effectiveSiteID(state.SiteID)
+	ctx, siteID := r.siteContext(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	policy, err := r.client.GetDNSPolicy(siteID, state.ID.ValueString())
+	policy, err := r.client.GetDNSPolicy(ctx, siteID, state.ID.ValueString())
 	if err != nil {
+		if unifi.IsNotFound(err) {
+			// DNS policy was deleted outside of Terraform
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Error reading DNS policy", err.Error())
 		return
 	}
@@ -330,9 +391,12 @@ func (r *DNSPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 		policy.TTL = int(plan.TTL.ValueInt64())
 	}
 
-	siteID := r.effectiveSiteID(plan.SiteID)
+	ctx, siteID := r.siteContext(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	_, err := r.client.UpdateDNSPolicy(siteID, plan.ID.ValueString(), policy)
+	_, err := r.client.UpdateDNSPolicy(ctx, siteID, plan.ID.ValueString(), policy)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating DNS policy", err.Error())
 		return
@@ -348,15 +412,24 @@ func (r *DNSPolicyResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	siteID := r.effectiveSiteID(state.SiteID)
+	ctx, siteID := r.siteContext(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	err := r.client.DeleteDNSPolicy(siteID, state.ID.ValueString())
+	err := r.client.DeleteDNSPolicy(ctx, siteID, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting DNS policy", err.Error())
 		return
 	}
 }
 
+// ImportState accepts <id>, or <site>/<id> for an object of another site.
 func (r *DNSPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, id := sitescope.ImportContext(ctx, r.client, req.ID, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
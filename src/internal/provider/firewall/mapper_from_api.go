@@ -26,11 +26,21 @@ func (r *FirewallPolicyResource) mapFromAPI(ctx context.Context, p *unifi.Firewa
 	} else {
 		data.Action.AllowReturnTraffic = types.BoolValue(false)
 	}
+	// Keep the prior filters so equivalent IP and MAC entries retain their spelling.
+	var priorSource, priorDestination *TrafficFilterModel
+	if data.Source != nil {
+		priorSource = data.Source.TrafficFilter
+	}
+	if data.Destination != nil {
+		priorDestination = data.Destination.TrafficFilter
+	}
 	data.Source = &SourceDestModel{
 		ZoneID: types.StringValue(p.Source.ZoneID),
+		Zone:   types.StringNull(), // filled in by refreshZoneNames
 	}
 	data.Destination = &SourceDestModel{
 		ZoneID: types.StringValue(p.Destination.ZoneID),
+		Zone:   types.StringNull(), // filled in by refreshZoneNames
 	}
 	data.IPProtocolScope = &IPProtocolScopeModel{
 		IPVersion: types.StringValue(p.IPProtocolScope.IPVersion),
@@ -60,6 +70,9 @@ func (r *FirewallPolicyResource) mapFromAPI(ctx context.Context, p *unifi.Firewa
 				data.Schedule.DaysOfWeek, _ = types.SetValueFrom(ctx, types.StringType, days)
 			}
 		}
+		if isRecurringSchedule(p.Schedule.Mode) {
+			data.Schedule.TimeRange = mapTimeRangeFromAPI(p.Schedule.TimeFilter)
+		}
 	}
 
 	if len(p.ConnectionStateFilter) > 0 {
@@ -70,10 +83,12 @@ func (r *FirewallPolicyResource) mapFromAPI(ctx context.Context, p *unifi.Firewa
 
 	if p.Source.TrafficFilter != nil {
 		data.Source.TrafficFilter = mapTrafficFilterFromAPI(ctx, p.Source.TrafficFilter)
+		keepConfiguredSpelling(ctx, data.Source.TrafficFilter, priorSource)
 	}
 
 	if p.Destination.TrafficFilter != nil {
 		data.Destination.TrafficFilter = mapTrafficFilterFromAPI(ctx, p.Destination.TrafficFilter)
+		keepConfiguredSpelling(ctx, data.Destination.TrafficFilter, priorDestination)
 	}
 
 	if p.IPProtocolScope.ProtocolFilter != nil {
@@ -91,6 +106,18 @@ func (r *FirewallPolicyResource) mapFromAPI(ctx context.Context, p *unifi.Firewa
 	}
 }
 
+// mapTimeRangeFromAPI returns nil for all-day schedules so that configs
+// without a time_range block stay clean.
+func mapTimeRangeFromAPI(tf *unifi.ScheduleTimeFilter) *TimeRangeModel {
+	if tf == nil || tf.Type != "TIME_RANGE" {
+		return nil
+	}
+	return &TimeRangeModel{
+		Start: types.StringValue(tf.StartTime),
+		Stop:  types.StringValue(tf.StopTime),
+	}
+}
+
 // mapDayNamesFromAPI converts full day names (MONDAY) from the API to short format (MON).
 func mapDayNamesFromAPI(days []string) []string {
 	fullToShort := map[string]string{
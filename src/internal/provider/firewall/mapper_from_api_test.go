@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
 )
 
@@ -186,6 +187,65 @@ func TestMapFromAPI_Schedule_EveryWeek(t *testing.T) {
 	}
 }
 
+func TestMapFromAPI_Schedule_TimeRange(t *testing.T) {
+	r := newTestResource()
+	p := minimalAPIPolicy()
+	p.Schedule = &unifi.FirewallSchedule{
+		Mode:       "EVERY_DAY",
+		TimeFilter: &unifi.ScheduleTimeFilter{Type: "TIME_RANGE", StartTime: "22:00", StopTime: "07:00"},
+	}
+	var data FirewallPolicyResourceModel
+
+	r.mapFromAPI(context.Background(), p, &data)
+
+	if data.Schedule == nil || data.Schedule.TimeRange == nil {
+		t.Fatal("expected schedule with time range")
+	}
+	if data.Schedule.TimeRange.Start.ValueString() != "22:00" {
+		t.Errorf("expected start '22:00', got %q", data.Schedule.TimeRange.Start.ValueString())
+	}
+	if data.Schedule.TimeRange.Stop.ValueString() != "07:00" {
+		t.Errorf("expected stop '07:00', got %q", data.Schedule.TimeRange.Stop.ValueString())
+	}
+}
+
+func TestMapFromAPI_Schedule_AllDay(t *testing.T) {
+	r := newTestResource()
+	p := minimalAPIPolicy()
+	p.Schedule = &unifi.FirewallSchedule{
+		Mode:       "EVERY_DAY",
+		TimeFilter: &unifi.ScheduleTimeFilter{Type: "ALL_DAY"},
+	}
+	var data FirewallPolicyResourceModel
+
+	r.mapFromAPI(context.Background(), p, &data)
+
+	if data.Schedule.TimeRange != nil {
+		t.Errorf("expected no time range for ALL_DAY, got %+v", data.Schedule.TimeRange)
+	}
+}
+
+func TestSchedule_TimeRangeRoundTrip(t *testing.T) {
+	r := newTestResource()
+	ctx := context.Background()
+	data := minimalTFModel()
+	data.Schedule = &FirewallScheduleModel{
+		Mode:      types.StringValue("CUSTOM"),
+		TimeRange: &TimeRangeModel{Start: types.StringValue("08:15"), Stop: types.StringValue("17:45")},
+	}
+
+	policy := r.mapToAPI(ctx, data)
+	var got FirewallPolicyResourceModel
+	r.mapFromAPI(ctx, &policy, &got)
+
+	if got.Schedule == nil || got.Schedule.TimeRange == nil {
+		t.Fatal("expected time range after round trip")
+	}
+	if !got.Schedule.TimeRange.Start.Equal(data.Schedule.TimeRange.Start) || !got.Schedule.TimeRange.Stop.Equal(data.Schedule.TimeRange.Stop) {
+		t.Errorf("round trip mismatch: got %+v", got.Schedule.TimeRange)
+	}
+}
+
 func TestMapFromAPI_ConnectionStateFilter(t *testing.T) {
 	r := newTestResource()
 	p := minimalAPIPolicy()
@@ -59,6 +59,9 @@ func (r *FirewallPolicyResource) mapToAPI(ctx context.Context, data FirewallPoli
 				policy.Schedule.RepeatOnDays = mapDayNamesToAPI(days)
 			}
 		}
+		if isRecurringSchedule(mode) {
+			policy.Schedule.TimeFilter = mapTimeRangeToAPI(data.Schedule.TimeRange)
+		}
 	}
 
 	if !data.ConnectionStateFilter.IsNull() {
@@ -91,6 +94,25 @@ func (r *FirewallPolicyResource) mapToAPI(ctx context.Context, data FirewallPoli
 	return policy
 }
 
+// isRecurringSchedule reports whether the schedule mode repeats and therefore
+// carries a time of day. ONE_TIME_ONLY uses absolute start/stop datetimes.
+func isRecurringSchedule(mode string) bool {
+	return mode == "EVERY_DAY" || mode == "EVERY_WEEK" || mode == "CUSTOM"
+}
+
+// mapTimeRangeToAPI converts the time_range block. Without one the schedule
+// applies all day.
+func mapTimeRangeToAPI(tr *TimeRangeModel) *unifi.ScheduleTimeFilter {
+	if tr == nil || tr.Start.IsNull() || tr.Stop.IsNull() {
+		return &unifi.ScheduleTimeFilter{Type: "ALL_DAY"}
+	}
+	return &unifi.ScheduleTimeFilter{
+		Type:      "TIME_RANGE",
+		StartTime: tr.Start.ValueString(),
+		StopTime:  tr.Stop.ValueString(),
+	}
+}
+
 // mapDayNamesToAPI converts short day names (MON) to the API's full format (MONDAY).
 // Passes through values that are already in full format.
 func mapDayNamesToAPI(days []string) []string {
@@ -182,6 +182,74 @@ func TestMapToAPI_Schedule_EveryWeek(t *testing.T) {
 	}
 }
 
+func TestMapToAPI_Schedule_TimeRange(t *testing.T) {
+	r := newTestResource()
+	ctx := context.Background()
+	daysSet, _ := types.SetValueFrom(ctx, types.StringType, []string{"MON", "TUE"})
+	data := minimalTFModel()
+	data.Schedule = &FirewallScheduleModel{
+		Mode:       types.StringValue("EVERY_WEEK"),
+		DaysOfWeek: daysSet,
+		TimeRange: &TimeRangeModel{
+			Start: types.StringValue("22:00"),
+			Stop:  types.StringValue("07:00"),
+		},
+	}
+
+	policy := r.mapToAPI(ctx, data)
+
+	tf := policy.Schedule.TimeFilter
+	if tf == nil {
+		t.Fatal("expected time filter")
+	}
+	if tf.Type != "TIME_RANGE" || tf.StartTime != "22:00" || tf.StopTime != "07:00" {
+		t.Errorf("expected overnight TIME_RANGE 22:00-07:00, got %+v", tf)
+	}
+}
+
+func TestMapToAPI_Schedule_AllDay(t *testing.T) {
+	r := newTestResource()
+	for _, mode := range []string{"EVERY_DAY", "EVERY_WEEK", "CUSTOM"} {
+		data := minimalTFModel()
+		data.Schedule = &FirewallScheduleModel{Mode: types.StringValue(mode)}
+
+		policy := r.mapToAPI(context.Background(), data)
+
+		if policy.Schedule.TimeFilter == nil || policy.Schedule.TimeFilter.Type != "ALL_DAY" {
+			t.Errorf("%s: expected ALL_DAY time filter, got %+v", mode, policy.Schedule.TimeFilter)
+		}
+	}
+}
+
+func TestMapToAPI_Schedule_OneTimeOnlyHasNoTimeFilter(t *testing.T) {
+	r := newTestResource()
+	data := minimalTFModel()
+	data.Schedule = &FirewallScheduleModel{
+		Mode:  types.StringValue("ONE_TIME_ONLY"),
+		Start: types.StringValue("2025-01-01T00:00:00Z"),
+		Stop:  types.StringValue("2025-01-02T00:00:00Z"),
+	}
+
+	policy := r.mapToAPI(context.Background(), data)
+
+	if policy.Schedule.TimeFilter != nil {
+		t.Errorf("expected no time filter, got %+v", policy.Schedule.TimeFilter)
+	}
+}
+
+func TestTimeOfDayRegex(t *testing.T) {
+	for _, v := range []string{"00:00", "07:30", "22:00", "23:59"} {
+		if !timeOfDayRegex.MatchString(v) {
+			t.Errorf("expected %q to be valid", v)
+		}
+	}
+	for _, v := range []string{"24:00", "7:30", "12:60", "12:00:00", "noon", ""} {
+		if timeOfDayRegex.MatchString(v) {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
+
 func TestMapToAPI_ConnectionStateFilter(t *testing.T) {
 	r := newTestResource()
 	ctx := context.Background()
@@ -2,6 +2,7 @@ package firewall
 
 import (
 	"context"
+	"net"
 	"sort"
 	"strings"
 
@@ -19,13 +20,14 @@ func mapTrafficFilterToAPI(ctx context.Context, tf *TrafficFilterModel) *unifi.T
 	}
 
 	if !tf.MACAddress.IsNull() {
-		apiTF.MACAddressFilter = tf.MACAddress.ValueString()
+		apiTF.MACAddressFilter = &unifi.MACAddressFilter{Address: canonicalMAC(tf.MACAddress.ValueString())}
 	}
 
 	if tf.PortFilter != nil {
 		apiTF.PortFilter = &unifi.PortFilter{
-			Type:          tf.PortFilter.Type.ValueString(),
-			MatchOpposite: tf.PortFilter.MatchOpposite.ValueBool(),
+			Type:                  tf.PortFilter.Type.ValueString(),
+			MatchOpposite:         tf.PortFilter.MatchOpposite.ValueBool(),
+			TrafficMatchingListID: tf.PortFilter.TrafficMatchingListID.ValueString(),
 		}
 		for _, item := range tf.PortFilter.Items {
 			pi := unifi.PortItem{
@@ -48,18 +50,12 @@ func mapTrafficFilterToAPI(ctx context.Context, tf *TrafficFilterModel) *unifi.T
 		var items []string
 		tf.IPAddressFilter.Items.ElementsAs(ctx, &items, false)
 		apiTF.IPAddressFilter = &unifi.IPAddressFilter{
-			Type:          tf.IPAddressFilter.Type.ValueString(),
-			MatchOpposite: tf.IPAddressFilter.MatchOpposite.ValueBool(),
+			Type:                  tf.IPAddressFilter.Type.ValueString(),
+			MatchOpposite:         tf.IPAddressFilter.MatchOpposite.ValueBool(),
+			TrafficMatchingListID: tf.IPAddressFilter.TrafficMatchingListID.ValueString(),
 		}
 		for _, item := range items {
-			itemType := "IP_ADDRESS"
-			if strings.Contains(item, "/") {
-				itemType = "SUBNET"
-			}
-			apiTF.IPAddressFilter.Items = append(apiTF.IPAddressFilter.Items, unifi.IPAddressItem{
-				Type:  itemType,
-				Value: item,
-			})
+			apiTF.IPAddressFilter.Items = append(apiTF.IPAddressFilter.Items, mapIPAddressItemToAPI(item))
 		}
 	}
 
@@ -67,7 +63,11 @@ func mapTrafficFilterToAPI(ctx context.Context, tf *TrafficFilterModel) *unifi.T
 		var items []string
 		tf.MACAddressFilter.Items.ElementsAs(ctx, &items, false)
 		apiTF.MACAddressFilter = &unifi.MACAddressFilter{
-			MACAddresses: items,
+			Type:          "MAC_ADDRESSES",
+			MatchOpposite: tf.MACAddressFilter.MatchOpposite.ValueBool(),
+		}
+		for _, item := range items {
+			apiTF.MACAddressFilter.MACAddresses = append(apiTF.MACAddressFilter.MACAddresses, canonicalMAC(item))
 		}
 	}
 
@@ -89,6 +89,54 @@ func mapTrafficFilterToAPI(ctx context.Context, tf *TrafficFilterModel) *unifi.T
 		apiTF.DomainFilter.Domains = items
 	}
 
+	if tf.IPv6IIDFilter != nil {
+		var items []string
+		tf.IPv6IIDFilter.Items.ElementsAs(ctx, &items, false)
+		apiTF.IPv6IIDFilter = &unifi.IPv6IIDFilter{
+			MatchOpposite: tf.IPv6IIDFilter.MatchOpposite.ValueBool(),
+			Items:         items,
+		}
+	}
+
+	if tf.VPNServerFilter != nil {
+		var items []string
+		tf.VPNServerFilter.Items.ElementsAs(ctx, &items, false)
+		apiTF.VPNServerFilter = &unifi.VPNServerFilter{
+			MatchOpposite: tf.VPNServerFilter.MatchOpposite.ValueBool(),
+			VPNServerIDs:  items,
+		}
+	}
+
+	if tf.SiteToSiteTunnelFilter != nil {
+		var items []string
+		tf.SiteToSiteTunnelFilter.Items.ElementsAs(ctx, &items, false)
+		apiTF.SiteToSiteVPNTunnelFilter = &unifi.SiteToSiteVPNTunnelFilter{
+			MatchOpposite: tf.SiteToSiteTunnelFilter.MatchOpposite.ValueBool(),
+			TunnelIDs:     items,
+		}
+	}
+
+	if tf.RegionFilter != nil {
+		var items []string
+		tf.RegionFilter.Items.ElementsAs(ctx, &items, false)
+		apiTF.RegionFilter = &unifi.RegionFilter{
+			MatchOpposite: tf.RegionFilter.MatchOpposite.ValueBool(),
+			Regions:       items,
+		}
+	}
+
+	if tf.ApplicationFilter != nil {
+		apiTF.ApplicationFilter = &unifi.ApplicationFilter{
+			ApplicationIDs:         int64SetToInts(ctx, tf.ApplicationFilter.ApplicationIDs),
+			ApplicationCategoryIDs: int64SetToInts(ctx, tf.ApplicationFilter.CategoryIDs),
+		}
+		if len(apiTF.ApplicationFilter.ApplicationIDs) > 0 {
+			apiTF.ApplicationFilter.Type = "APPLICATIONS"
+		} else {
+			apiTF.ApplicationFilter.Type = "APPLICATION_CATEGORIES"
+		}
+	}
+
 	return apiTF
 }
 
@@ -104,36 +152,32 @@ func mapTrafficFilterFromAPI(ctx context.Context, apiTF *unifi.TrafficFilter) *T
 
 	hasContent := false
 
-	if v, ok := apiTF.MACAddressFilter.(string); ok && v != "" {
-		tf.MACAddress = types.StringValue(v)
+	if f := apiTF.MACAddressFilter; f != nil && f.Address != "" {
+		tf.MACAddress = types.StringValue(canonicalMAC(f.Address))
 		hasContent = true
-	}
-
-	// Handle polymorphic MACAddressFilter
-	if macFilter, ok := apiTF.MACAddressFilter.(*unifi.MACAddressFilter); ok && len(macFilter.MACAddresses) > 0 {
+	} else if f != nil && len(f.MACAddresses) > 0 {
+		macs := make([]string, 0, len(f.MACAddresses))
+		for _, mac := range f.MACAddresses {
+			macs = append(macs, canonicalMAC(mac))
+		}
 		tf.MACAddressFilter = &MACAddressFilterModel{
 			Type:          types.StringValue("MAC_ADDRESSES"),
-			MatchOpposite: types.BoolValue(false),
+			MatchOpposite: types.BoolValue(f.MatchOpposite),
 		}
-		tf.MACAddressFilter.Items, _ = types.SetValueFrom(ctx, types.StringType, macFilter.MACAddresses)
+		tf.MACAddressFilter.Items, _ = types.SetValueFrom(ctx, types.StringType, macs)
 		hasContent = true
-	} else if listMacs, ok := apiTF.MACAddressFilter.(map[string]interface{}); ok {
-		// Fallback for raw map if needed (e.g. from generic JSON unmarshal)
-		if macs, ok := listMacs["macAddresses"].([]interface{}); ok && len(macs) > 0 {
-			var ms []string
-			for _, m := range macs {
-				ms = append(ms, m.(string))
-			}
-			tf.MACAddressFilter = &MACAddressFilterModel{
-				Type:          types.StringValue("MAC_ADDRESSES"),
-				MatchOpposite: types.BoolValue(false),
-			}
-			tf.MACAddressFilter.Items, _ = types.SetValueFrom(ctx, types.StringType, ms)
-			hasContent = true
-		}
 	}
 
-	if apiTF.PortFilter != nil && len(apiTF.PortFilter.Items) > 0 {
+	if apiTF.PortFilter != nil && apiTF.PortFilter.TrafficMatchingListID != "" {
+		// A referenced list replaces the inline items.
+		tf.PortFilter = &PortFilterModel{
+			Type:                  types.StringValue(apiTF.PortFilter.Type),
+			MatchOpposite:         types.BoolValue(apiTF.PortFilter.MatchOpposite),
+			Items:                 []PortItemModel{},
+			TrafficMatchingListID: types.StringValue(apiTF.PortFilter.TrafficMatchingListID),
+		}
+		hasContent = true
+	} else if apiTF.PortFilter != nil && len(apiTF.PortFilter.Items) > 0 {
 		var items []PortItemModel
 		for _, item := range apiTF.PortFilter.Items {
 			pi := PortItemModel{
@@ -172,7 +216,15 @@ func mapTrafficFilterFromAPI(ctx context.Context, apiTF *unifi.TrafficFilter) *T
 		hasContent = true
 	}
 
-	if apiTF.IPAddressFilter != nil && len(apiTF.IPAddressFilter.Items) > 0 {
+	if apiTF.IPAddressFilter != nil && apiTF.IPAddressFilter.TrafficMatchingListID != "" {
+		tf.IPAddressFilter = &IPAddressFilterModel{
+			Type:                  types.StringValue(apiTF.IPAddressFilter.Type),
+			MatchOpposite:         types.BoolValue(apiTF.IPAddressFilter.MatchOpposite),
+			Items:                 types.SetNull(types.StringType),
+			TrafficMatchingListID: types.StringValue(apiTF.IPAddressFilter.TrafficMatchingListID),
+		}
+		hasContent = true
+	} else if apiTF.IPAddressFilter != nil && len(apiTF.IPAddressFilter.Items) > 0 {
 		tf.IPAddressFilter = &IPAddressFilterModel{}
 		if apiTF.IPAddressFilter.Type != "" {
 			tf.IPAddressFilter.Type = types.StringValue(apiTF.IPAddressFilter.Type)
@@ -180,7 +232,7 @@ func mapTrafficFilterFromAPI(ctx context.Context, apiTF *unifi.TrafficFilter) *T
 		tf.IPAddressFilter.MatchOpposite = types.BoolValue(apiTF.IPAddressFilter.MatchOpposite)
 		var ms []string
 		for _, item := range apiTF.IPAddressFilter.Items {
-			ms = append(ms, item.Value)
+			ms = append(ms, mapIPAddressItemFromAPI(item))
 		}
 		tf.IPAddressFilter.Items, _ = types.SetValueFrom(ctx, types.StringType, ms)
 		hasContent = true
@@ -188,7 +240,9 @@ func mapTrafficFilterFromAPI(ctx context.Context, apiTF *unifi.TrafficFilter) *T
 
 	if apiTF.NetworkFilter != nil && len(apiTF.NetworkFilter.NetworkIDs) > 0 {
 		tf.NetworkFilter = &NetworkFilterModel{
-			Type: types.StringValue("NETWORK"),
+			Type:    types.StringValue("NETWORK"),
+			Names:   types.SetNull(types.StringType),
+			VLANIDs: types.SetNull(types.Int64Type),
 		}
 		tf.NetworkFilter.MatchOpposite = types.BoolValue(apiTF.NetworkFilter.MatchOpposite)
 		tf.NetworkFilter.Items, _ = types.SetValueFrom(ctx, types.StringType, apiTF.NetworkFilter.NetworkIDs)
@@ -201,9 +255,149 @@ func mapTrafficFilterFromAPI(ctx context.Context, apiTF *unifi.TrafficFilter) *T
 		hasContent = true
 	}
 
+	if apiTF.IPv6IIDFilter != nil && len(apiTF.IPv6IIDFilter.Items) > 0 {
+		tf.IPv6IIDFilter = &IPv6IIDFilterModel{
+			MatchOpposite: types.BoolValue(apiTF.IPv6IIDFilter.MatchOpposite),
+		}
+		tf.IPv6IIDFilter.Items, _ = types.SetValueFrom(ctx, types.StringType, apiTF.IPv6IIDFilter.Items)
+		hasContent = true
+	}
+
+	if apiTF.VPNServerFilter != nil && len(apiTF.VPNServerFilter.VPNServerIDs) > 0 {
+		tf.VPNServerFilter = &VPNServerFilterModel{
+			MatchOpposite: types.BoolValue(apiTF.VPNServerFilter.MatchOpposite),
+		}
+		tf.VPNServerFilter.Items, _ = types.SetValueFrom(ctx, types.StringType, apiTF.VPNServerFilter.VPNServerIDs)
+		hasContent = true
+	}
+
+	if apiTF.SiteToSiteVPNTunnelFilter != nil && len(apiTF.SiteToSiteVPNTunnelFilter.TunnelIDs) > 0 {
+		tf.SiteToSiteTunnelFilter = &SiteToSiteTunnelFilterModel{
+			MatchOpposite: types.BoolValue(apiTF.SiteToSiteVPNTunnelFilter.MatchOpposite),
+		}
+		tf.SiteToSiteTunnelFilter.Items, _ = types.SetValueFrom(ctx, types.StringType, apiTF.SiteToSiteVPNTunnelFilter.TunnelIDs)
+		hasContent = true
+	}
+
+	if apiTF.RegionFilter != nil && len(apiTF.RegionFilter.Regions) > 0 {
+		tf.RegionFilter = &RegionFilterModel{
+			MatchOpposite: types.BoolValue(apiTF.RegionFilter.MatchOpposite),
+		}
+		tf.RegionFilter.Items, _ = types.SetValueFrom(ctx, types.StringType, apiTF.RegionFilter.Regions)
+		hasContent = true
+	}
+
+	if af := apiTF.ApplicationFilter; af != nil && (len(af.ApplicationIDs) > 0 || len(af.ApplicationCategoryIDs) > 0) {
+		tf.ApplicationFilter = &ApplicationFilterModel{
+			ApplicationIDs: intsToInt64Set(ctx, af.ApplicationIDs),
+			CategoryIDs:    intsToInt64Set(ctx, af.ApplicationCategoryIDs),
+		}
+		hasContent = true
+	}
+
 	if !hasContent {
 		return nil
 	}
 
 	return tf
 }
+
+// mapIPAddressItemToAPI classifies an ip_address_filter entry as an address,
+// subnet or range. Entries that do not parse are rejected by ValidateConfig;
+// they are passed through as-is here.
+func mapIPAddressItemToAPI(item string) unifi.IPAddressItem {
+	entry, err := parseAddressEntry(item)
+	if err != nil {
+		itemType := "IP_ADDRESS"
+		if strings.Contains(item, "/") {
+			itemType = "SUBNET"
+		}
+		return unifi.IPAddressItem{Type: itemType, Value: item}
+	}
+	return unifi.IPAddressItem{Type: entry.Type, Value: entry.Value, Start: entry.Start, Stop: entry.Stop}
+}
+
+// mapIPAddressItemFromAPI is the inverse of mapIPAddressItemToAPI.
+func mapIPAddressItemFromAPI(item unifi.IPAddressItem) string {
+	if item.Type == "IP_ADDRESS_RANGE" {
+		return item.Start + "-" + item.Stop
+	}
+	return item.Value
+}
+
+// keepConfiguredSpelling rewrites the IP and MAC addresses read from the API
+// to the spelling used in prior, when they are equivalent, so that
+// "10.0.0.1/32" or "AA-BB-CC-DD-EE-FF" in configuration does not drift. Network
+// names and VLAN IDs are taken from prior as well.
+func keepConfiguredSpelling(ctx context.Context, tf, prior *TrafficFilterModel) {
+	if tf == nil || prior == nil {
+		return
+	}
+	if tf.IPAddressFilter != nil && prior.IPAddressFilter != nil {
+		tf.IPAddressFilter.Items = keepConfiguredItems(ctx, tf.IPAddressFilter.Items, prior.IPAddressFilter.Items, canonicalAddressEntry)
+	}
+	if tf.MACAddressFilter != nil && prior.MACAddressFilter != nil {
+		tf.MACAddressFilter.Items = keepConfiguredItems(ctx, tf.MACAddressFilter.Items, prior.MACAddressFilter.Items, canonicalMAC)
+	}
+	if isKnown(tf.MACAddress) && isKnown(prior.MACAddress) && canonicalMAC(prior.MACAddress.ValueString()) == tf.MACAddress.ValueString() {
+		tf.MACAddress = prior.MACAddress
+	}
+	// The controller only knows network IDs; the names and VLAN IDs they were
+	// resolved from are configuration and carry over as they are.
+	if tf.NetworkFilter != nil && prior.NetworkFilter != nil {
+		if !prior.NetworkFilter.Names.IsUnknown() && !prior.NetworkFilter.Names.IsNull() {
+			tf.NetworkFilter.Names = prior.NetworkFilter.Names
+		}
+		if !prior.NetworkFilter.VLANIDs.IsUnknown() && !prior.NetworkFilter.VLANIDs.IsNull() {
+			tf.NetworkFilter.VLANIDs = prior.NetworkFilter.VLANIDs
+		}
+	}
+}
+
+func keepConfiguredItems(ctx context.Context, observed, prior types.Set, canonical func(string) string) types.Set {
+	configured := knownStrings(prior)
+	if len(configured) == 0 {
+		return observed
+	}
+
+	var items []string
+	observed.ElementsAs(ctx, &items, false)
+	set, _ := types.SetValueFrom(ctx, types.StringType, preferConfiguredSpelling(items, configured, canonical))
+	return set
+}
+
+// canonicalMAC returns mac in lower case with colon separators, the form the
+// controller uses, or mac itself when it does not parse.
+func canonicalMAC(mac string) string {
+	hw, err := net.ParseMAC(mac)
+	if err != nil || len(hw) != 6 {
+		return mac
+	}
+	return hw.String()
+}
+
+// int64SetToInts returns the elements of a set of numbers as ints.
+func int64SetToInts(ctx context.Context, set types.Set) []int {
+	var values []int64
+	set.ElementsAs(ctx, &values, false)
+
+	ints := make([]int, 0, len(values))
+	for _, v := range values {
+		ints = append(ints, int(v))
+	}
+	return ints
+}
+
+// intsToInt64Set is the inverse of int64SetToInts. An empty slice yields a
+// null set, matching an omitted attribute.
+func intsToInt64Set(ctx context.Context, ints []int) types.Set {
+	if len(ints) == 0 {
+		return types.SetNull(types.Int64Type)
+	}
+	values := make([]int64, 0, len(ints))
+	for _, v := range ints {
+		values = append(values, int64(v))
+	}
+	set, _ := types.SetValueFrom(ctx, types.Int64Type, values)
+	return set
+}
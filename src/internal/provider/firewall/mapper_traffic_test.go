@@ -2,6 +2,7 @@ package firewall
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -100,12 +101,11 @@ func TestMapTrafficFilterToAPI_MACAddress(t *testing.T) {
 	if result == nil {
 		t.Fatal("expected non-nil result")
 	}
-	macStr, ok := result.MACAddressFilter.(string)
-	if !ok {
-		t.Fatal("expected MACAddressFilter to be a string")
+	if result.MACAddressFilter == nil || result.MACAddressFilter.Address == "" {
+		t.Fatalf("expected MACAddressFilter in its string form, got %+v", result.MACAddressFilter)
 	}
-	if macStr != "AA:BB:CC:DD:EE:FF" {
-		t.Errorf("expected 'AA:BB:CC:DD:EE:FF', got %q", macStr)
+	if result.MACAddressFilter.Address != "aa:bb:cc:dd:ee:ff" {
+		t.Errorf("expected normalized 'aa:bb:cc:dd:ee:ff', got %q", result.MACAddressFilter.Address)
 	}
 }
 
@@ -116,7 +116,7 @@ func TestMapTrafficFilterToAPI_MACAddressFilter(t *testing.T) {
 		Type: types.StringValue("MAC_ADDRESS"),
 		MACAddressFilter: &MACAddressFilterModel{
 			Type:          types.StringValue("MAC_ADDRESSES"),
-			MatchOpposite: types.BoolValue(false),
+			MatchOpposite: types.BoolValue(true),
 			Items:         items,
 		},
 	}
@@ -125,13 +125,21 @@ func TestMapTrafficFilterToAPI_MACAddressFilter(t *testing.T) {
 	if result == nil {
 		t.Fatal("expected non-nil result")
 	}
-	macFilter, ok := result.MACAddressFilter.(*unifi.MACAddressFilter)
-	if !ok {
-		t.Fatalf("expected *MACAddressFilter, got %T", result.MACAddressFilter)
+	macFilter := result.MACAddressFilter
+	if macFilter == nil || macFilter.Address != "" {
+		t.Fatalf("expected MACAddressFilter in its object form, got %+v", macFilter)
 	}
 	if len(macFilter.MACAddresses) != 2 {
 		t.Errorf("expected 2 MACs, got %d", len(macFilter.MACAddresses))
 	}
+	if !macFilter.MatchOpposite || macFilter.Type != "MAC_ADDRESSES" {
+		t.Errorf("expected type MAC_ADDRESSES with MatchOpposite=true, got %+v", macFilter)
+	}
+	for _, mac := range macFilter.MACAddresses {
+		if mac != "11:22:33:44:55:66" && mac != "aa:bb:cc:dd:ee:ff" {
+			t.Errorf("expected normalized MAC, got %q", mac)
+		}
+	}
 }
 
 func TestMapTrafficFilterToAPI_NetworkFilter(t *testing.T) {
@@ -274,19 +282,196 @@ func TestMapTrafficFilterFromAPI_IPAddressFilter(t *testing.T) {
 	}
 }
 
+func TestMapTrafficFilterToAPI_IPAddressRange(t *testing.T) {
+	ctx := context.Background()
+	items, _ := types.SetValueFrom(ctx, types.StringType, []string{"10.0.0.10-10.0.0.50", "2001:db8::/64", "10.0.0.1/32"})
+	tf := &TrafficFilterModel{
+		Type: types.StringValue("IP_ADDRESS"),
+		IPAddressFilter: &IPAddressFilterModel{
+			Type:          types.StringValue("IP_ADDRESSES"),
+			MatchOpposite: types.BoolValue(false),
+			Items:         items,
+		},
+	}
+
+	result := mapTrafficFilterToAPI(ctx, tf)
+	byType := map[string]unifi.IPAddressItem{}
+	for _, item := range result.IPAddressFilter.Items {
+		byType[item.Type] = item
+	}
+	if r := byType["IP_ADDRESS_RANGE"]; r.Start != "10.0.0.10" || r.Stop != "10.0.0.50" || r.Value != "" {
+		t.Errorf("unexpected range item: %+v", r)
+	}
+	if s := byType["SUBNET"]; s.Value != "2001:db8::/64" {
+		t.Errorf("expected IPv6 subnet, got %+v", s)
+	}
+	if a := byType["IP_ADDRESS"]; a.Value != "10.0.0.1" {
+		t.Errorf("expected host route to be sent as an address, got %+v", a)
+	}
+}
+
+func TestMapTrafficFilterFromAPI_IPAddressRange(t *testing.T) {
+	ctx := context.Background()
+	apiTF := &unifi.TrafficFilter{
+		Type: "IP_ADDRESS",
+		IPAddressFilter: &unifi.IPAddressFilter{
+			Type: "IP_ADDRESSES",
+			Items: []unifi.IPAddressItem{
+				{Type: "IP_ADDRESS_RANGE", Start: "10.0.0.10", Stop: "10.0.0.50"},
+				{Type: "IP_ADDRESS", Value: "10.0.0.1"},
+			},
+		},
+	}
+
+	result := mapTrafficFilterFromAPI(ctx, apiTF)
+	if !result.IPAddressFilter.Items.Equal(stringSet("10.0.0.1", "10.0.0.10-10.0.0.50")) {
+		t.Errorf("unexpected items: %v", result.IPAddressFilter.Items)
+	}
+
+	// A host route in configuration must not drift against the plain
+	// address the controller returns.
+	prior := &TrafficFilterModel{
+		IPAddressFilter: &IPAddressFilterModel{Items: stringSet("10.0.0.1/32", "10.0.0.10-10.0.0.50")},
+	}
+	keepConfiguredSpelling(ctx, result, prior)
+	if !result.IPAddressFilter.Items.Equal(prior.IPAddressFilter.Items) {
+		t.Errorf("expected configured spelling to be kept, got %v", result.IPAddressFilter.Items)
+	}
+}
+
+func TestMapTrafficFilter_ApplicationFilterRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	apps, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{65539, 262256})
+	tf := &TrafficFilterModel{
+		Type: types.StringValue("APPLICATION"),
+		ApplicationFilter: &ApplicationFilterModel{
+			ApplicationIDs: apps,
+			CategoryIDs:    types.SetNull(types.Int64Type),
+		},
+	}
+
+	apiTF := mapTrafficFilterToAPI(ctx, tf)
+	af := apiTF.ApplicationFilter
+	if af == nil || af.Type != "APPLICATIONS" || len(af.ApplicationIDs) != 2 || len(af.ApplicationCategoryIDs) != 0 {
+		t.Fatalf("unexpected application filter: %+v", af)
+	}
+
+	got := mapTrafficFilterFromAPI(ctx, apiTF)
+	if got == nil || got.ApplicationFilter == nil {
+		t.Fatal("expected application filter after round trip")
+	}
+	if !got.ApplicationFilter.ApplicationIDs.Equal(apps) || !got.ApplicationFilter.CategoryIDs.IsNull() {
+		t.Errorf("application filter drifted: %+v", got.ApplicationFilter)
+	}
+
+	categoryIDs, _ := types.SetValueFrom(ctx, types.Int64Type, []int64{24})
+	categories := mapTrafficFilterToAPI(ctx, &TrafficFilterModel{
+		Type: types.StringValue("APPLICATION"),
+		ApplicationFilter: &ApplicationFilterModel{
+			ApplicationIDs: types.SetNull(types.Int64Type),
+			CategoryIDs:    categoryIDs,
+		},
+	})
+	if af := categories.ApplicationFilter; af.Type != "APPLICATION_CATEGORIES" || len(af.ApplicationCategoryIDs) != 1 || af.ApplicationCategoryIDs[0] != 24 {
+		t.Errorf("unexpected category filter: %+v", af)
+	}
+}
+
+func TestMapTrafficFilter_RegionFilterRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	tf := &TrafficFilterModel{
+		Type: types.StringValue("REGION"),
+		RegionFilter: &RegionFilterModel{
+			MatchOpposite: types.BoolValue(true),
+			Items:         stringSet("BE", "NL", "LU"),
+		},
+	}
+
+	apiTF := mapTrafficFilterToAPI(ctx, tf)
+	if apiTF.RegionFilter == nil || !apiTF.RegionFilter.MatchOpposite || len(apiTF.RegionFilter.Regions) != 3 {
+		t.Fatalf("unexpected region filter: %+v", apiTF.RegionFilter)
+	}
+
+	got := mapTrafficFilterFromAPI(ctx, apiTF)
+	if got == nil || got.RegionFilter == nil {
+		t.Fatal("expected region filter after round trip")
+	}
+	if !got.RegionFilter.Items.Equal(tf.RegionFilter.Items) || !got.RegionFilter.MatchOpposite.ValueBool() {
+		t.Errorf("region filter drifted: %+v", got.RegionFilter)
+	}
+}
+
+func TestMapTrafficFilter_VPNFiltersRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	tf := &TrafficFilterModel{
+		Type: types.StringValue("VPN_SERVER"),
+		VPNServerFilter: &VPNServerFilterModel{
+			MatchOpposite: types.BoolValue(false),
+			Items:         stringSet("vpn-1"),
+		},
+		SiteToSiteTunnelFilter: &SiteToSiteTunnelFilterModel{
+			MatchOpposite: types.BoolValue(true),
+			Items:         stringSet("s2s-1", "s2s-2"),
+		},
+	}
+
+	apiTF := mapTrafficFilterToAPI(ctx, tf)
+	if apiTF.VPNServerFilter == nil || len(apiTF.VPNServerFilter.VPNServerIDs) != 1 || apiTF.VPNServerFilter.VPNServerIDs[0] != "vpn-1" {
+		t.Fatalf("unexpected VPN server filter: %+v", apiTF.VPNServerFilter)
+	}
+	if apiTF.SiteToSiteVPNTunnelFilter == nil || !apiTF.SiteToSiteVPNTunnelFilter.MatchOpposite || len(apiTF.SiteToSiteVPNTunnelFilter.TunnelIDs) != 2 {
+		t.Fatalf("unexpected tunnel filter: %+v", apiTF.SiteToSiteVPNTunnelFilter)
+	}
+
+	got := mapTrafficFilterFromAPI(ctx, apiTF)
+	if got == nil || got.VPNServerFilter == nil || got.SiteToSiteTunnelFilter == nil {
+		t.Fatalf("expected both VPN filters after round trip, got %+v", got)
+	}
+	if !got.VPNServerFilter.Items.Equal(tf.VPNServerFilter.Items) || got.VPNServerFilter.MatchOpposite.ValueBool() {
+		t.Errorf("VPN server filter drifted: %+v", got.VPNServerFilter)
+	}
+	if !got.SiteToSiteTunnelFilter.Items.Equal(tf.SiteToSiteTunnelFilter.Items) || !got.SiteToSiteTunnelFilter.MatchOpposite.ValueBool() {
+		t.Errorf("tunnel filter drifted: %+v", got.SiteToSiteTunnelFilter)
+	}
+}
+
+func TestMapTrafficFilter_IPv6IIDFilterRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	tf := &TrafficFilterModel{
+		Type: types.StringValue("IPV6_IID"),
+		IPv6IIDFilter: &IPv6IIDFilterModel{
+			MatchOpposite: types.BoolValue(true),
+			Items:         stringSet("::1a2b:3c4d:5e6f:7a8b"),
+		},
+	}
+
+	apiTF := mapTrafficFilterToAPI(ctx, tf)
+	if apiTF.IPv6IIDFilter == nil || !apiTF.IPv6IIDFilter.MatchOpposite || len(apiTF.IPv6IIDFilter.Items) != 1 {
+		t.Fatalf("unexpected IID filter: %+v", apiTF.IPv6IIDFilter)
+	}
+
+	got := mapTrafficFilterFromAPI(ctx, apiTF)
+	if got == nil || got.IPv6IIDFilter == nil {
+		t.Fatal("expected IID filter after round trip")
+	}
+	if !got.IPv6IIDFilter.Items.Equal(tf.IPv6IIDFilter.Items) || !got.IPv6IIDFilter.MatchOpposite.ValueBool() {
+		t.Errorf("IID filter drifted: %+v", got.IPv6IIDFilter)
+	}
+}
+
 func TestMapTrafficFilterFromAPI_MACAddress_String(t *testing.T) {
 	ctx := context.Background()
 	apiTF := &unifi.TrafficFilter{
 		Type:             "MAC_ADDRESS",
-		MACAddressFilter: "AA:BB:CC:DD:EE:FF",
+		MACAddressFilter: &unifi.MACAddressFilter{Address: "AA:BB:CC:DD:EE:FF"},
 	}
 
 	result := mapTrafficFilterFromAPI(ctx, apiTF)
 	if result == nil {
 		t.Fatal("expected non-nil result")
 	}
-	if result.MACAddress.ValueString() != "AA:BB:CC:DD:EE:FF" {
-		t.Errorf("expected 'AA:BB:CC:DD:EE:FF', got %q", result.MACAddress.ValueString())
+	if result.MACAddress.ValueString() != "aa:bb:cc:dd:ee:ff" {
+		t.Errorf("expected 'aa:bb:cc:dd:ee:ff', got %q", result.MACAddress.ValueString())
 	}
 }
 
@@ -308,22 +493,56 @@ func TestMapTrafficFilterFromAPI_MACAddress_Struct(t *testing.T) {
 	}
 }
 
-func TestMapTrafficFilterFromAPI_MACAddress_RawMap(t *testing.T) {
+func TestMapTrafficFilterFromAPI_MACAddress_JSON(t *testing.T) {
 	ctx := context.Background()
-	// Simulates raw JSON unmarshal where MACAddressFilter is a map
-	apiTF := &unifi.TrafficFilter{
-		Type: "MAC_ADDRESS",
-		MACAddressFilter: map[string]interface{}{
-			"macAddresses": []interface{}{"AA:BB:CC:DD:EE:FF"},
+	// Both wire forms, as decoded from a controller response.
+	body := `[
+		{"type": "IP_ADDRESS", "macAddressFilter": "AA-BB-CC-DD-EE-FF"},
+		{"type": "MAC_ADDRESS", "macAddressFilter": {"type": "MAC_ADDRESSES", "matchOpposite": true, "macAddresses": ["11:22:33:44:55:66"]}}
+	]`
+	var filters []unifi.TrafficFilter
+	if err := json.Unmarshal([]byte(body), &filters); err != nil {
+		t.Fatal(err)
+	}
+
+	additional := mapTrafficFilterFromAPI(ctx, &filters[0])
+	if additional == nil || additional.MACAddress.ValueString() != "aa:bb:cc:dd:ee:ff" || additional.MACAddressFilter != nil {
+		t.Errorf("unexpected additional MAC filter: %+v", additional)
+	}
+
+	standalone := mapTrafficFilterFromAPI(ctx, &filters[1])
+	if standalone == nil || standalone.MACAddressFilter == nil {
+		t.Fatal("expected MACAddressFilter model from the object form")
+	}
+	if !standalone.MACAddressFilter.MatchOpposite.ValueBool() {
+		t.Error("expected match_opposite to be read from the API")
+	}
+	if !standalone.MACAddressFilter.Items.Equal(stringSet("11:22:33:44:55:66")) {
+		t.Errorf("unexpected items: %v", standalone.MACAddressFilter.Items)
+	}
+}
+
+func TestKeepConfiguredSpelling_MACAddresses(t *testing.T) {
+	ctx := context.Background()
+	got := &TrafficFilterModel{
+		MACAddress: types.StringValue("aa:bb:cc:dd:ee:ff"),
+		MACAddressFilter: &MACAddressFilterModel{
+			Items: stringSet("11:22:33:44:55:66", "aa:bb:cc:dd:ee:01"),
+		},
+	}
+	prior := &TrafficFilterModel{
+		MACAddress: types.StringValue("AA:BB:CC:DD:EE:FF"),
+		MACAddressFilter: &MACAddressFilterModel{
+			Items: stringSet("11-22-33-44-55-66", "AA:BB:CC:DD:EE:02"),
 		},
 	}
 
-	result := mapTrafficFilterFromAPI(ctx, apiTF)
-	if result == nil {
-		t.Fatal("expected non-nil result")
+	keepConfiguredSpelling(ctx, got, prior)
+	if got.MACAddress.ValueString() != "AA:BB:CC:DD:EE:FF" {
+		t.Errorf("expected configured spelling of mac_address, got %q", got.MACAddress.ValueString())
 	}
-	if result.MACAddressFilter == nil {
-		t.Fatal("expected MACAddressFilter model from raw map")
+	if !got.MACAddressFilter.Items.Equal(stringSet("11-22-33-44-55-66", "aa:bb:cc:dd:ee:01")) {
+		t.Errorf("expected configured spelling only for equivalent MACs, got %v", got.MACAddressFilter.Items)
 	}
 }
 
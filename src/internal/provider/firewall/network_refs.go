@@ -0,0 +1,119 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+// networkRef is a network_filter entry given by name or VLAN ID rather than
+// by network ID.
+type networkRef struct {
+	name   string
+	vlanID int
+	byVLAN bool
+}
+
+func (ref networkRef) String() string {
+	if ref.byVLAN {
+		return fmt.Sprintf("VLAN ID %d", ref.vlanID)
+	}
+	return fmt.Sprintf("name %q", ref.name)
+}
+
+// findNetworkID returns the ID of the single network matching ref. Names are
+// not guaranteed to be unique, so several matches are an error.
+func findNetworkID(networks []unifi.Network, ref networkRef) (string, error) {
+	var found *unifi.Network
+	for i := range networks {
+		n := &networks[i]
+		if ref.byVLAN && n.VlanID != ref.vlanID || !ref.byVLAN && n.Name != ref.name {
+			continue
+		}
+		if found != nil {
+			return "", fmt.Errorf("more than one network with %s (IDs %s and %s); list the one you mean by ID in items", ref, found.ID, n.ID)
+		}
+		found = n
+	}
+	if found == nil {
+		return "", fmt.Errorf("network with %s not found", ref)
+	}
+	return found.ID, nil
+}
+
+// resolveNetworkFilters fills in the items of every network_filter in plan
+// that is configured by names or vlan_ids. Networks are listed at most once,
+// and only when there is something to resolve. It reports whether plan was
+// changed.
+func (r *FirewallPolicyResource) resolveNetworkFilters(ctx context.Context, plan *FirewallPolicyResourceModel, diags *diag.Diagnostics) bool {
+	var networks []unifi.Network
+	listed := false
+	changed := false
+
+	for _, side := range policySides(plan) {
+		sd := side.sd
+		if sd == nil || sd.TrafficFilter == nil || sd.TrafficFilter.NetworkFilter == nil {
+			continue
+		}
+		nf := sd.TrafficFilter.NetworkFilter
+		if nf.Names.IsNull() && nf.VLANIDs.IsNull() {
+			continue
+		}
+		changed = true
+
+		// Names computed by other resources, or a provider that is not
+		// configured yet, leave the IDs to be known after apply.
+		if !setKnown(nf.Names) || !setKnown(nf.VLANIDs) || r.client == nil {
+			nf.Items = types.SetUnknown(types.StringType)
+			continue
+		}
+
+		if !listed {
+			var err error
+			networks, err = r.client.ListNetworks(ctx)
+			if err != nil {
+				diags.AddError("Error listing networks", err.Error())
+				return false
+			}
+			listed = true
+		}
+
+		p := path.Root(side.name).AtName("traffic_filter").AtName("network_filter")
+		var ids []string
+		resolve := func(attribute string, ref networkRef) {
+			id, err := findNetworkID(networks, ref)
+			if err != nil {
+				diags.AddAttributeError(p.AtName(attribute), "Unknown network", err.Error())
+				return
+			}
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		for _, name := range knownStrings(nf.Names) {
+			resolve("names", networkRef{name: name})
+		}
+		for _, vlanID := range int64SetToInts(ctx, nf.VLANIDs) {
+			resolve("vlan_ids", networkRef{vlanID: vlanID, byVLAN: true})
+		}
+
+		items, d := types.SetValueFrom(ctx, types.StringType, ids)
+		diags.Append(d...)
+		nf.Items = items
+	}
+	return changed
+}
+
+// setKnown reports whether set and all of its elements are known.
+func setKnown(set types.Set) bool {
+	if set.IsUnknown() {
+		return false
+	}
+	return !slices.ContainsFunc(set.Elements(), attr.Value.IsUnknown)
+}
@@ -0,0 +1,201 @@
+package firewall
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var testNetworks = []unifi.Network{
+	{ID: "net-1", Name: "Default", VlanID: 1},
+	{ID: "net-2", Name: "IoT", VlanID: 30},
+	{ID: "net-3", Name: "Guest", VlanID: 100},
+	{ID: "net-4", Name: "Guest", VlanID: 101},
+}
+
+func TestFindNetworkID(t *testing.T) {
+	if id, err := findNetworkID(testNetworks, networkRef{name: "IoT"}); err != nil || id != "net-2" {
+		t.Errorf("by name: got %q, %v", id, err)
+	}
+	if id, err := findNetworkID(testNetworks, networkRef{vlanID: 100, byVLAN: true}); err != nil || id != "net-3" {
+		t.Errorf("by VLAN: got %q, %v", id, err)
+	}
+
+	_, err := findNetworkID(testNetworks, networkRef{name: "Guest"})
+	if err == nil || !strings.Contains(err.Error(), "net-3 and net-4") {
+		t.Errorf("expected an ambiguity error naming both IDs, got %v", err)
+	}
+	_, err = findNetworkID(testNetworks, networkRef{name: "iot"})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected names to match exactly, got %v", err)
+	}
+	_, err = findNetworkID(testNetworks, networkRef{vlanID: 200, byVLAN: true})
+	if err == nil || !strings.Contains(err.Error(), "VLAN ID 200") {
+		t.Errorf("expected a not found error for VLAN 200, got %v", err)
+	}
+}
+
+func TestResolveNetworkFilters_WithoutClient(t *testing.T) {
+	data := minimalTFModel()
+	data.Source.TrafficFilter = &TrafficFilterModel{
+		Type: types.StringValue("NETWORK"),
+		NetworkFilter: &NetworkFilterModel{
+			Items:   types.SetNull(types.StringType),
+			Names:   stringSet("IoT"),
+			VLANIDs: types.SetNull(types.Int64Type),
+		},
+	}
+
+	var diags diag.Diagnostics
+	if !newTestResource().resolveNetworkFilters(context.Background(), &data, &diags) {
+		t.Fatal("expected the plan to change")
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if !data.Source.TrafficFilter.NetworkFilter.Items.IsUnknown() {
+		t.Errorf("expected items to be unknown until the provider is configured, got %v", data.Source.TrafficFilter.NetworkFilter.Items)
+	}
+}
+
+func TestCreate_ResolvesNetworkFiltersUnknownAtPlan(t *testing.T) {
+	ctx := context.Background()
+	policies := map[string][]unifi.FirewallPolicy{"site-1": nil}
+	r := &FirewallPolicyResource{client: newMockClient(t, policies, nil,
+		map[string][]unifi.Network{"site-1": testNetworks},
+	)}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	emptyState := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	// The names came from another resource, so ModifyPlan left items unknown;
+	// at apply time they are known.
+	data := minimalTFModel()
+	data.Site = types.StringValue("site-1")
+	data.Source.Zone = types.StringNull()
+	data.Destination.Zone = types.StringNull()
+	data.Source.TrafficFilter = &TrafficFilterModel{
+		Type: types.StringValue("NETWORK"),
+		NetworkFilter: &NetworkFilterModel{
+			MatchOpposite: types.BoolValue(false),
+			Items:         types.SetUnknown(types.StringType),
+			Names:         stringSet("IoT"),
+			VLANIDs:       int64Set(t, 1),
+		},
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: emptyState.Raw.Copy()}
+	if diags := plan.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("cannot build plan: %v", diags)
+	}
+
+	createResp := resource.CreateResponse{State: emptyState}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", createResp.Diagnostics)
+	}
+
+	var got FirewallPolicyResourceModel
+	createResp.State.Get(ctx, &got)
+	want := stringSet("net-1", "net-2")
+	if items := got.Source.TrafficFilter.NetworkFilter.Items; !items.Equal(want) {
+		t.Errorf("expected items %v in state, got %v", want, items)
+	}
+	sent := policies["site-1"][0].Source.TrafficFilter.NetworkFilter.NetworkIDs
+	if len(sent) != 2 {
+		t.Errorf("expected both networks to be sent, got %v", sent)
+	}
+
+	// Update resolves them the same way.
+	data.ID = got.ID
+	data.Source.TrafficFilter.NetworkFilter.Names = stringSet("Default")
+	data.Source.TrafficFilter.NetworkFilter.VLANIDs = types.SetNull(types.Int64Type)
+	plan = tfsdk.Plan{Schema: schemaResp.Schema, Raw: emptyState.Raw.Copy()}
+	plan.Set(ctx, &data)
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", updateResp.Diagnostics)
+	}
+	updateResp.State.Get(ctx, &got)
+	if items := got.Source.TrafficFilter.NetworkFilter.Items; !items.Equal(stringSet("net-1")) {
+		t.Errorf("update: expected items [net-1] in state, got %v", items)
+	}
+}
+
+func int64Set(t *testing.T, values ...int64) types.Set {
+	t.Helper()
+	set, diags := types.SetValueFrom(context.Background(), types.Int64Type, values)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	return set
+}
+
+func TestValidatePolicy_NetworkFilterReferences(t *testing.T) {
+	vlans, _ := types.SetValueFrom(context.Background(), types.Int64Type, []int64{30, 5000})
+	cases := []struct {
+		name   string
+		filter *NetworkFilterModel
+		attr   string
+	}{
+		{"items with names", &NetworkFilterModel{Items: stringSet("net-1"), Names: stringSet("IoT"), VLANIDs: types.SetNull(types.Int64Type)},
+			"source.traffic_filter.network_filter.items"},
+		{"nothing to match", &NetworkFilterModel{Items: types.SetNull(types.StringType), Names: types.SetNull(types.StringType), VLANIDs: types.SetNull(types.Int64Type)},
+			"source.traffic_filter.network_filter"},
+		{"VLAN out of range", &NetworkFilterModel{Items: types.SetNull(types.StringType), Names: types.SetNull(types.StringType), VLANIDs: vlans},
+			"source.traffic_filter.network_filter.vlan_ids"},
+	}
+	for _, tc := range cases {
+		data := minimalTFModel()
+		data.Source.TrafficFilter = &TrafficFilterModel{Type: types.StringValue("NETWORK"), NetworkFilter: tc.filter}
+		if _, ok := errorAt(validatePolicy(data), tc.attr); !ok {
+			t.Errorf("%s: expected an error at %s", tc.name, tc.attr)
+		}
+	}
+
+	data := minimalTFModel()
+	data.Source.TrafficFilter = &TrafficFilterModel{
+		Type: types.StringValue("NETWORK"),
+		NetworkFilter: &NetworkFilterModel{
+			Items:   types.SetNull(types.StringType),
+			Names:   stringSet("IoT"),
+			VLANIDs: types.SetNull(types.Int64Type),
+		},
+	}
+	if diags := validatePolicy(data); diags.HasError() {
+		t.Errorf("expected a network filter by name to be valid, got %v", diags)
+	}
+}
+
+func TestKeepConfiguredSpelling_NetworkReferences(t *testing.T) {
+	ctx := context.Background()
+	prior := &TrafficFilterModel{
+		NetworkFilter: &NetworkFilterModel{
+			Items:   stringSet("net-2"),
+			Names:   stringSet("IoT"),
+			VLANIDs: types.SetNull(types.Int64Type),
+		},
+	}
+	tf := mapTrafficFilterFromAPI(ctx, &unifi.TrafficFilter{
+		Type:          "NETWORK",
+		NetworkFilter: &unifi.NetworkFilter{NetworkIDs: []string{"net-2"}},
+	})
+	keepConfiguredSpelling(ctx, tf, prior)
+
+	if !tf.NetworkFilter.Names.Equal(stringSet("IoT")) {
+		t.Errorf("expected names to carry over, got %v", tf.NetworkFilter.Names)
+	}
+	if !tf.NetworkFilter.VLANIDs.IsNull() {
+		t.Errorf("expected vlan_ids to stay null, got %v", tf.NetworkFilter.VLANIDs)
+	}
+	if !tf.NetworkFilter.Items.Equal(stringSet("net-2")) {
+		t.Errorf("expected the resolved ID in items, got %v", tf.NetworkFilter.Items)
+	}
+}
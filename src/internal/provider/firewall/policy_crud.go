@@ -5,6 +5,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
 )
 
 func (r *FirewallPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -14,9 +16,23 @@ func (r *FirewallPolicyResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	ctx = sitescope.Context(ctx, r.client, &data.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Zone IDs or names, network names and VLAN IDs computed by other
+	// resources, or on a site that was unknown at plan time, are only known
+	// now.
+	r.resolveZoneReferences(ctx, &data, &resp.Diagnostics)
+	r.resolveNetworkFilters(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	policy := r.mapToAPI(ctx, data)
 
-	created, err := r.client.CreateFirewallPolicy(policy)
+	created, err := r.client.CreateFirewallPolicy(ctx, policy)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating firewall policy", err.Error())
 		return
@@ -33,13 +49,24 @@ func (r *FirewallPolicyResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	policy, err := r.client.GetFirewallPolicy(data.ID.ValueString())
+	ctx = sitescope.Context(ctx, r.client, &data.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := r.client.GetFirewallPolicy(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.State.RemoveResource(ctx)
+		if unifi.IsNotFound(err) {
+			// Policy was deleted outside of Terraform
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading firewall policy", err.Error())
 		return
 	}
 
 	r.mapFromAPI(ctx, policy, &data)
+	r.refreshZoneNames(ctx, &data, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -51,9 +78,20 @@ func (r *FirewallPolicyResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	ctx = sitescope.Context(ctx, r.client, &plan.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.resolveZoneReferences(ctx, &plan, &resp.Diagnostics)
+	r.resolveNetworkFilters(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	policy := r.mapToAPI(ctx, plan)
 
-	_, err := r.client.UpdateFirewallPolicy(state.ID.ValueString(), policy)
+	_, err := r.client.UpdateFirewallPolicy(ctx, state.ID.ValueString(), policy)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating firewall policy", err.Error())
 		return
@@ -70,7 +108,12 @@ func (r *FirewallPolicyResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	err := r.client.DeleteFirewallPolicy(data.ID.ValueString())
+	ctx = sitescope.Context(ctx, r.client, &data.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteFirewallPolicy(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting firewall policy", err.Error())
 		return
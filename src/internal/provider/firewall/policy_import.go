@@ -0,0 +1,71 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+// importNamePrefix marks an import ID that should be resolved by policy name
+// rather than used as a policy UUID, e.g. "name:Block IoT to LAN".
+const importNamePrefix = "name:"
+
+// ImportState accepts either a policy UUID or "name:<policy name>", prefixed
+// with "<site>/" for a policy of another site. The full model is populated by
+// the Read that Terraform runs right after import.
+func (r *FirewallPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Policy names may contain a slash, so a bare name import has no site.
+	id := req.ID
+	if !strings.HasPrefix(id, importNamePrefix) {
+		ctx, id = sitescope.ImportContext(ctx, r.client, req.ID, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if strings.HasPrefix(id, importNamePrefix) {
+		policies, err := r.client.ListFirewallPolicies(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing firewall policies", err.Error())
+			return
+		}
+
+		id, err = resolvePolicyImportID(policies, id)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot import firewall policy", err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// resolvePolicyImportID resolves a "name:<policy name>" import ID to the UUID
+// of the single policy carrying that name.
+func resolvePolicyImportID(policies []unifi.FirewallPolicy, importID string) (string, error) {
+	name := strings.TrimPrefix(importID, importNamePrefix)
+	if name == "" {
+		return "", fmt.Errorf("import ID %q has an empty policy name", importID)
+	}
+
+	var matches []string
+	for _, p := range policies {
+		if p.Name == name {
+			matches = append(matches, p.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no firewall policy found with name %q", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("name %q matches %d firewall policies (%s); import by ID instead", name, len(matches), strings.Join(matches, ", "))
+	}
+}
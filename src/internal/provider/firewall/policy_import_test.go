@@ -0,0 +1,313 @@
+package firewall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+func importTestPolicies() []unifi.FirewallPolicy {
+	return []unifi.FirewallPolicy{
+		{ID: "fw-1", Name: "Allow SSH"},
+		{ID: "fw-2", Name: "Block IoT"},
+		{ID: "fw-3", Name: "Duplicate"},
+		{ID: "fw-4", Name: "Duplicate"},
+	}
+}
+
+func TestResolvePolicyImportID_ByName(t *testing.T) {
+	id, err := resolvePolicyImportID(importTestPolicies(), "name:Block IoT")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "fw-2" {
+		t.Errorf("expected 'fw-2', got %q", id)
+	}
+}
+
+func TestResolvePolicyImportID_NotFound(t *testing.T) {
+	_, err := resolvePolicyImportID(importTestPolicies(), "name:Missing")
+	if err == nil {
+		t.Fatal("expected error for unknown name")
+	}
+	if !strings.Contains(err.Error(), "Missing") {
+		t.Errorf("expected policy name in error, got: %s", err)
+	}
+}
+
+func TestResolvePolicyImportID_Ambiguous(t *testing.T) {
+	_, err := resolvePolicyImportID(importTestPolicies(), "name:Duplicate")
+	if err == nil {
+		t.Fatal("expected error for ambiguous name")
+	}
+	if !strings.Contains(err.Error(), "fw-3") || !strings.Contains(err.Error(), "fw-4") {
+		t.Errorf("expected both matching IDs in error, got: %s", err)
+	}
+}
+
+func TestResolvePolicyImportID_EmptyName(t *testing.T) {
+	_, err := resolvePolicyImportID(importTestPolicies(), "name:")
+	if err == nil {
+		t.Fatal("expected error for empty name")
+	}
+}
+
+// newMockClient returns a client for site-1 backed by a server that lists the
+// given firewall policies, zones and networks of each site. Every site named
+// in a map is listed as a site, with its ID as name and internal reference.
+// Policies that are created or updated are stored in policies.
+func newMockClient(t *testing.T, policies map[string][]unifi.FirewallPolicy, zones map[string][]unifi.FirewallZone, networks map[string][]unifi.Network) *unifi.Client {
+	t.Helper()
+	sites := []unifi.Site{}
+	seen := map[string]bool{}
+	for _, ids := range [][]string{mapKeys(policies), mapKeys(zones), mapKeys(networks)} {
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				sites = append(sites, unifi.Site{ID: id, Name: id, InternalReference: id})
+			}
+		}
+	}
+
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		respond := func(v interface{}) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(v)
+		}
+
+		if r.URL.Path == "/v1/sites" {
+			respond(map[string]interface{}{"data": sites})
+			return
+		}
+		site, route := splitSitePath(r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && route == "firewall/policies":
+			respond(map[string]interface{}{"data": policies[site]})
+		case r.Method == http.MethodGet && route == "firewall/zones":
+			respond(map[string]interface{}{"data": zones[site]})
+		case r.Method == http.MethodGet && route == "networks":
+			respond(map[string]interface{}{"data": networks[site]})
+		case r.Method == http.MethodPost && route == "firewall/policies":
+			var policy unifi.FirewallPolicy
+			json.NewDecoder(r.Body).Decode(&policy)
+			policy.ID = fmt.Sprintf("fw-new-%d", len(policies[site])+1)
+			policies[site] = append(policies[site], policy)
+			respond(policy)
+		case r.Method == http.MethodPut && strings.HasPrefix(route, "firewall/policies/"):
+			var policy unifi.FirewallPolicy
+			json.NewDecoder(r.Body).Decode(&policy)
+			policy.ID = strings.TrimPrefix(route, "firewall/policies/")
+			for i := range policies[site] {
+				if policies[site][i].ID == policy.ID {
+					policies[site][i] = policy
+				}
+			}
+			respond(policy)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return unifi.NewClient(srv.URL, "key", "site-1", false)
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// splitSitePath splits /v1/sites/<site>/<route>.
+func splitSitePath(path string) (site, route string) {
+	site, route, _ = strings.Cut(strings.TrimPrefix(path, "/v1/sites/"), "/")
+	return site, route
+}
+
+// importAndRead runs ImportState with importID followed by Read, as Terraform
+// does for "terraform import", and returns the resulting state.
+func importAndRead(t *testing.T, r *FirewallPolicyResource, importID string) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	empty := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	importResp := resource.ImportStateResponse{State: empty}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: importID}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("import failed: %v", importResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", readResp.Diagnostics)
+	}
+	return readResp.State
+}
+
+// importedPolicyConfig is the model of a configuration that uses most policy
+// features, with optional attributes left unset where config would omit them.
+func importedPolicyConfig() FirewallPolicyResourceModel {
+	days := stringSet("MON", "FRI")
+	return FirewallPolicyResourceModel{
+		Enabled:     types.BoolValue(true),
+		Name:        types.StringValue("Block IoT to LAN"),
+		Description: types.StringValue("Imported from the UI"),
+		Action: &ActionModel{
+			Type:               types.StringValue("BLOCK"),
+			AllowReturnTraffic: types.BoolValue(false),
+		},
+		Source: &SourceDestModel{
+			ZoneID: types.StringValue("zone-iot"),
+			TrafficFilter: &TrafficFilterModel{
+				Type: types.StringValue("IP_ADDRESS"),
+				IPAddressFilter: &IPAddressFilterModel{
+					Type:          types.StringValue("IP_ADDRESSES"),
+					MatchOpposite: types.BoolValue(false),
+					Items:         stringSet("10.0.30.5", "10.0.31.0/24"),
+				},
+			},
+		},
+		Destination: &SourceDestModel{
+			ZoneID: types.StringValue("zone-lan"),
+			TrafficFilter: &TrafficFilterModel{
+				Type:       types.StringValue("PORT"),
+				PortFilter: portFilter(portNumber(22), portRange(8000, 8080)),
+			},
+		},
+		IPProtocolScope:       tcpProtocolScope("IPV4"),
+		ConnectionStateFilter: types.SetNull(types.StringType),
+		LoggingEnabled:        types.BoolValue(true),
+		Schedule: &FirewallScheduleModel{
+			Mode:       types.StringValue("EVERY_WEEK"),
+			DaysOfWeek: days,
+			TimeRange: &TimeRangeModel{
+				Start: types.StringValue("22:00"),
+				Stop:  types.StringValue("06:00"),
+			},
+		},
+	}
+}
+
+func TestImportState_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	minimal := minimalTFModel()
+	minimal.Name = types.StringValue("Allow established")
+	minimal.ConnectionStateFilter = stringSet("ESTABLISHED", "RELATED")
+	configs := map[string]FirewallPolicyResourceModel{
+		"fw-imported": importedPolicyConfig(),
+		"fw-minimal":  minimal,
+	}
+
+	var policies []unifi.FirewallPolicy
+	for id, config := range configs {
+		policy := newTestResource().mapToAPI(ctx, config)
+		policy.ID = id
+		policies = append(policies, policy)
+	}
+	zones := []unifi.FirewallZone{
+		{ID: "zone-iot", Name: "IoT"}, {ID: "zone-lan", Name: "Internal"},
+		{ID: "zone-src", Name: "Source"}, {ID: "zone-dst", Name: "Destination"},
+	}
+	r := &FirewallPolicyResource{client: newMockClient(t,
+		map[string][]unifi.FirewallPolicy{"site-1": policies},
+		map[string][]unifi.FirewallZone{"site-1": zones},
+		nil,
+	)}
+
+	for id, config := range configs {
+		for _, importID := range []string{id, "name:" + config.Name.ValueString()} {
+			got := importAndRead(t, r, importID)
+
+			// What a plan of the same configuration holds once computed
+			// attributes are known.
+			want := config
+			want.ID = types.StringValue(id)
+			want.Site = types.StringValue("site-1")
+			want.Source = &SourceDestModel{ZoneID: config.Source.ZoneID, Zone: zoneName(zones, config.Source.ZoneID.ValueString()), TrafficFilter: config.Source.TrafficFilter}
+			want.Destination = &SourceDestModel{ZoneID: config.Destination.ZoneID, Zone: zoneName(zones, config.Destination.ZoneID.ValueString()), TrafficFilter: config.Destination.TrafficFilter}
+			wantState := tfsdk.State{Schema: got.Schema, Raw: got.Raw.Copy()}
+			if diags := wantState.Set(ctx, &want); diags.HasError() {
+				t.Fatalf("cannot build expected state: %v", diags)
+			}
+
+			if !got.Raw.Equal(wantState.Raw) {
+				diffs, _ := got.Raw.Diff(wantState.Raw)
+				for _, d := range diffs {
+					t.Errorf("%s: %s: imported %v, config %v", importID, d.Path, d.Value1, d.Value2)
+				}
+			}
+		}
+	}
+}
+
+func TestImportState_OtherSite(t *testing.T) {
+	r := &FirewallPolicyResource{client: newMockClient(t,
+		map[string][]unifi.FirewallPolicy{
+			"site-1": {{ID: "fw-local", Name: "Allow SSH"}},
+			"site-2": {{ID: "fw-remote", Name: "Allow SSH"}},
+		},
+		map[string][]unifi.FirewallZone{},
+		nil,
+	)}
+
+	for _, importID := range []string{"site-2/fw-remote", "site-2/name:Allow SSH"} {
+		got := importAndRead(t, r, importID)
+
+		var m FirewallPolicyResourceModel
+		if diags := got.Get(context.Background(), &m); diags.HasError() {
+			t.Fatalf("%s: %v", importID, diags)
+		}
+		if m.ID.ValueString() != "fw-remote" || m.Site.ValueString() != "site-2" {
+			t.Errorf("%s: expected fw-remote on site-2, got %s on %s", importID, m.ID, m.Site)
+		}
+	}
+
+	// Without a site prefix the name is looked up on the provider's site.
+	got := importAndRead(t, r, "name:Allow SSH")
+	var id types.String
+	got.GetAttribute(context.Background(), path.Root("id"), &id)
+	if id.ValueString() != "fw-local" {
+		t.Errorf("expected fw-local on the provider's site, got %s", id)
+	}
+}
+
+func TestImportState_InvalidSite(t *testing.T) {
+	r := &FirewallPolicyResource{client: newMockClient(t, map[string][]unifi.FirewallPolicy{"site-1": nil}, nil, nil)}
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	for _, importID := range []string{"site-9/fw-1", "/fw-1", "site-1/"} {
+		resp := resource.ImportStateResponse{State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: importID}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("%s: expected an error", importID)
+		}
+	}
+}
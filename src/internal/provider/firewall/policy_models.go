@@ -17,6 +17,7 @@ type FirewallPolicyResourceModel struct {
 	LoggingEnabled        types.Bool             `tfsdk:"logging_enabled"`
 	IPsecFilter           types.String           `tfsdk:"ipsec_filter"`
 	Schedule              *FirewallScheduleModel `tfsdk:"schedule"`
+	Site                  types.String           `tfsdk:"site"`
 }
 
 type FirewallScheduleModel struct {
@@ -39,23 +40,30 @@ type ActionModel struct {
 
 type SourceDestModel struct {
 	ZoneID        types.String        `tfsdk:"zone_id"`
+	Zone          types.String        `tfsdk:"zone"`
 	TrafficFilter *TrafficFilterModel `tfsdk:"traffic_filter"`
 }
 
 type TrafficFilterModel struct {
-	Type             types.String           `tfsdk:"type"`
-	PortFilter       *PortFilterModel       `tfsdk:"port_filter"`
-	DomainFilter     *DomainFilterModel     `tfsdk:"domain_filter"`
-	IPAddressFilter  *IPAddressFilterModel  `tfsdk:"ip_address_filter"`
-	MACAddressFilter *MACAddressFilterModel `tfsdk:"mac_address_filter"`
-	NetworkFilter    *NetworkFilterModel    `tfsdk:"network_filter"`
-	MACAddress       types.String           `tfsdk:"mac_address"`
+	Type                   types.String                 `tfsdk:"type"`
+	PortFilter             *PortFilterModel             `tfsdk:"port_filter"`
+	DomainFilter           *DomainFilterModel           `tfsdk:"domain_filter"`
+	IPAddressFilter        *IPAddressFilterModel        `tfsdk:"ip_address_filter"`
+	MACAddressFilter       *MACAddressFilterModel       `tfsdk:"mac_address_filter"`
+	NetworkFilter          *NetworkFilterModel          `tfsdk:"network_filter"`
+	MACAddress             types.String                 `tfsdk:"mac_address"`
+	ApplicationFilter      *ApplicationFilterModel      `tfsdk:"application_filter"`
+	RegionFilter           *RegionFilterModel           `tfsdk:"region_filter"`
+	VPNServerFilter        *VPNServerFilterModel        `tfsdk:"vpn_server_filter"`
+	SiteToSiteTunnelFilter *SiteToSiteTunnelFilterModel `tfsdk:"site_to_site_tunnel_filter"`
+	IPv6IIDFilter          *IPv6IIDFilterModel          `tfsdk:"ipv6_iid_filter"`
 }
 
 type IPAddressFilterModel struct {
-	Type          types.String `tfsdk:"type"`
-	MatchOpposite types.Bool   `tfsdk:"match_opposite"`
-	Items         types.Set    `tfsdk:"items"`
+	Type                  types.String `tfsdk:"type"`
+	MatchOpposite         types.Bool   `tfsdk:"match_opposite"`
+	Items                 types.Set    `tfsdk:"items"`
+	TrafficMatchingListID types.String `tfsdk:"traffic_matching_list_id"`
 }
 
 type MACAddressFilterModel struct {
@@ -68,12 +76,15 @@ type NetworkFilterModel struct {
 	Type          types.String `tfsdk:"type"`
 	MatchOpposite types.Bool   `tfsdk:"match_opposite"`
 	Items         types.Set    `tfsdk:"items"`
+	Names         types.Set    `tfsdk:"names"`
+	VLANIDs       types.Set    `tfsdk:"vlan_ids"`
 }
 
 type PortFilterModel struct {
-	Type          types.String    `tfsdk:"type"`
-	MatchOpposite types.Bool      `tfsdk:"match_opposite"`
-	Items         []PortItemModel `tfsdk:"items"`
+	Type                  types.String    `tfsdk:"type"`
+	MatchOpposite         types.Bool      `tfsdk:"match_opposite"`
+	Items                 []PortItemModel `tfsdk:"items"`
+	TrafficMatchingListID types.String    `tfsdk:"traffic_matching_list_id"`
 }
 
 type PortItemModel struct {
@@ -83,6 +94,31 @@ type PortItemModel struct {
 	Stop  types.Int32  `tfsdk:"stop"`
 }
 
+type IPv6IIDFilterModel struct {
+	MatchOpposite types.Bool `tfsdk:"match_opposite"`
+	Items         types.Set  `tfsdk:"items"`
+}
+
+type VPNServerFilterModel struct {
+	MatchOpposite types.Bool `tfsdk:"match_opposite"`
+	Items         types.Set  `tfsdk:"items"`
+}
+
+type SiteToSiteTunnelFilterModel struct {
+	MatchOpposite types.Bool `tfsdk:"match_opposite"`
+	Items         types.Set  `tfsdk:"items"`
+}
+
+type RegionFilterModel struct {
+	MatchOpposite types.Bool `tfsdk:"match_opposite"`
+	Items         types.Set  `tfsdk:"items"`
+}
+
+type ApplicationFilterModel struct {
+	ApplicationIDs types.Set `tfsdk:"application_ids"`
+	CategoryIDs    types.Set `tfsdk:"category_ids"`
+}
+
 type DomainFilterModel struct {
 	Items types.Set `tfsdk:"items"`
 }
@@ -0,0 +1,275 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var (
+	_ resource.Resource                = &FirewallPolicyOrderResource{}
+	_ resource.ResourceWithConfigure   = &FirewallPolicyOrderResource{}
+	_ resource.ResourceWithImportState = &FirewallPolicyOrderResource{}
+)
+
+func NewFirewallPolicyOrderResource() resource.Resource {
+	return &FirewallPolicyOrderResource{}
+}
+
+// FirewallPolicyOrderResource manages the evaluation order of firewall
+// policies for one source/destination zone pair.
+type FirewallPolicyOrderResource struct {
+	client *unifi.Client
+}
+
+type FirewallPolicyOrderResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	SourceZoneID      types.String `tfsdk:"source_zone_id"`
+	DestinationZoneID types.String `tfsdk:"destination_zone_id"`
+	PolicyIDs         types.List   `tfsdk:"policy_ids"`
+	Site              types.String `tfsdk:"site"`
+}
+
+func (r *FirewallPolicyOrderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_policy_order"
+}
+
+func (r *FirewallPolicyOrderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Controls the order in which firewall policies are evaluated for a source/destination zone pair. " +
+			"The listed policies are placed first, in the given order; other policies of the pair keep their relative order below them. " +
+			"Destroying this resource leaves the current order on the controller unchanged.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The zone pair, as `<source_zone_id>:<destination_zone_id>`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"site": sitescope.ResourceAttribute(func() *unifi.Client { return r.client }),
+			"source_zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the source firewall zone.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"destination_zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the destination firewall zone.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "IDs of `unifi_fw` policies in this zone pair, in evaluation order.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+		},
+	}
+}
+
+func (r *FirewallPolicyOrderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*unifi.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", "Expected *unifi.Client")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FirewallPolicyOrderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan FirewallPolicyOrderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &plan.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FirewallPolicyOrderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state FirewallPolicyOrderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &state.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ordering, err := r.client.GetFirewallPolicyOrdering(ctx, state.SourceZoneID.ValueString(), state.DestinationZoneID.ValueString())
+	if err != nil {
+		if unifi.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading firewall policy order", err.Error())
+		return
+	}
+
+	// After import there is no configured list yet, so report the full order.
+	n := -1
+	if !state.PolicyIDs.IsNull() {
+		n = len(state.PolicyIDs.Elements())
+	}
+
+	policyIDs, diags := types.ListValueFrom(ctx, types.StringType, observedPolicyOrder(*ordering, n))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.PolicyIDs = policyIDs
+	state.ID = types.StringValue(policyOrderID(state.SourceZoneID.ValueString(), state.DestinationZoneID.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *FirewallPolicyOrderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan FirewallPolicyOrderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &plan.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete only forgets the resource. The controller always has an order for
+// every zone pair, so there is nothing to remove.
+func (r *FirewallPolicyOrderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// ImportState accepts <source_zone_id>:<destination_zone_id>, prefixed with
+// <site>/ for zones of another site.
+func (r *FirewallPolicyOrderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id := sitescope.ImportContext(ctx, r.client, req.ID, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source, destination, ok := strings.Cut(id, ":")
+	if !ok || source == "" || destination == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected [<site>/]<source_zone_id>:<destination_zone_id>, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_zone_id"), source)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("destination_zone_id"), destination)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy_ids"), types.ListNull(types.StringType))...)
+}
+
+// apply writes the planned order to the controller and fills in the ID.
+func (r *FirewallPolicyOrderResource) apply(ctx context.Context, plan *FirewallPolicyOrderResourceModel, diags *diag.Diagnostics) {
+	source := plan.SourceZoneID.ValueString()
+	destination := plan.DestinationZoneID.ValueString()
+
+	managed := make([]string, 0, len(plan.PolicyIDs.Elements()))
+	for _, v := range plan.PolicyIDs.Elements() {
+		managed = append(managed, v.(types.String).ValueString())
+	}
+
+	current, err := r.client.GetFirewallPolicyOrdering(ctx, source, destination)
+	if err != nil {
+		diags.AddError("Error reading firewall policy order", err.Error())
+		return
+	}
+
+	if _, err := r.client.UpdateFirewallPolicyOrdering(ctx, source, destination, applyPolicyOrder(*current, managed)); err != nil {
+		diags.AddError("Error updating firewall policy order", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(policyOrderID(source, destination))
+}
+
+func policyOrderID(sourceZoneID, destinationZoneID string) string {
+	return sourceZoneID + ":" + destinationZoneID
+}
+
+// applyPolicyOrder puts managed at the top of the zone pair's order. Policies
+// not listed keep their relative order below the managed ones, and policies
+// evaluated after the system-defined rules stay there.
+func applyPolicyOrder(current unifi.FirewallPolicyOrdering, managed []string) unifi.FirewallPolicyOrdering {
+	isManaged := make(map[string]bool, len(managed))
+	for _, id := range managed {
+		isManaged[id] = true
+	}
+
+	ordering := unifi.FirewallPolicyOrdering{
+		BeforeSystemDefined: append([]string{}, managed...),
+		AfterSystemDefined:  []string{},
+	}
+	for _, id := range current.BeforeSystemDefined {
+		if !isManaged[id] {
+			ordering.BeforeSystemDefined = append(ordering.BeforeSystemDefined, id)
+		}
+	}
+	for _, id := range current.AfterSystemDefined {
+		if !isManaged[id] {
+			ordering.AfterSystemDefined = append(ordering.AfterSystemDefined, id)
+		}
+	}
+	return ordering
+}
+
+// observedPolicyOrder returns the IDs in the first n positions of the
+// controller's order. When rules were reordered outside Terraform these no
+// longer match policy_ids, which surfaces as drift. A negative n returns the
+// whole order.
+func observedPolicyOrder(current unifi.FirewallPolicyOrdering, n int) []string {
+	ids := current.BeforeSystemDefined
+	if n >= 0 && n < len(ids) {
+		ids = ids[:n]
+	}
+	return append([]string{}, ids...)
+}
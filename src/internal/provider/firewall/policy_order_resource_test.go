@@ -0,0 +1,71 @@
+package firewall
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+func TestApplyPolicyOrder(t *testing.T) {
+	current := unifi.FirewallPolicyOrdering{
+		BeforeSystemDefined: []string{"allow-a", "allow-b", "block-x"},
+		AfterSystemDefined:  []string{"late-1", "block-y"},
+	}
+
+	got := applyPolicyOrder(current, []string{"block-x", "block-y"})
+
+	wantBefore := []string{"block-x", "block-y", "allow-a", "allow-b"}
+	wantAfter := []string{"late-1"}
+	if !reflect.DeepEqual(got.BeforeSystemDefined, wantBefore) {
+		t.Errorf("before: got %v, want %v", got.BeforeSystemDefined, wantBefore)
+	}
+	if !reflect.DeepEqual(got.AfterSystemDefined, wantAfter) {
+		t.Errorf("after: got %v, want %v", got.AfterSystemDefined, wantAfter)
+	}
+	if current.BeforeSystemDefined[0] != "allow-a" {
+		t.Error("applyPolicyOrder must not modify the current ordering")
+	}
+}
+
+func TestApplyPolicyOrder_EmptyAfterIsNotNull(t *testing.T) {
+	got := applyPolicyOrder(unifi.FirewallPolicyOrdering{}, []string{"p1"})
+	if got.AfterSystemDefined == nil {
+		t.Error("expected an empty, non-nil afterSystemDefined list so it serializes as []")
+	}
+}
+
+func TestObservedPolicyOrder(t *testing.T) {
+	current := unifi.FirewallPolicyOrdering{
+		BeforeSystemDefined: []string{"p2", "p1", "p3"},
+		AfterSystemDefined:  []string{"p4"},
+	}
+
+	tests := []struct {
+		name string
+		n    int
+		want []string
+	}{
+		{"prefix", 2, []string{"p2", "p1"}},
+		{"longer than order", 5, []string{"p2", "p1", "p3"}},
+		{"import", -1, []string{"p2", "p1", "p3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := observedPolicyOrder(current, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObservedPolicyOrder_DetectsDrift(t *testing.T) {
+	// Someone dragged an unmanaged rule above the managed ones in the UI.
+	current := unifi.FirewallPolicyOrdering{
+		BeforeSystemDefined: []string{"allow-a", "block-x", "block-y"},
+	}
+	got := observedPolicyOrder(current, 2)
+	if reflect.DeepEqual(got, []string{"block-x", "block-y"}) {
+		t.Errorf("expected drift to be visible, got %v", got)
+	}
+}
@@ -5,9 +5,17 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
 	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
 )
 
+var (
+	_ resource.Resource                = &FirewallPolicyResource{}
+	_ resource.ResourceWithConfigure   = &FirewallPolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &FirewallPolicyResource{}
+	_ resource.ResourceWithImportState = &FirewallPolicyResource{}
+)
+
 type FirewallPolicyResource struct {
 	client *unifi.Client
 }
@@ -46,6 +54,8 @@ func (r *FirewallPolicyResource) ModifyPlan(ctx context.Context, req resource.Mo
 		return
 	}
 
+	changed := false
+
 	// Smart Default Logic for allow_return_traffic
 	if plan.Action != nil {
 		if plan.Action.AllowReturnTraffic.IsUnknown() || plan.Action.AllowReturnTraffic.IsNull() {
@@ -53,7 +63,43 @@ func (r *FirewallPolicyResource) ModifyPlan(ctx context.Context, req resource.Mo
 			// certain zone combinations (e.g. External destination). Users can
 			// explicitly set it to true where the API allows it.
 			plan.Action.AllowReturnTraffic = types.BoolValue(false)
-			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+			changed = true
+		}
+	}
+
+	// zone_id and zone keep their prior values unless the other one changed.
+	if !req.State.Raw.IsNull() {
+		var state FirewallPolicyResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+		if forgetStaleZoneReferences(&plan, &state) {
+			changed = true
+		}
+	}
+
+	// Zones and network filters may be given by name; the API wants IDs. They
+	// are looked up on the policy's site, so wait until it is known.
+	if !plan.Site.IsUnknown() {
+		if r.client != nil {
+			ctx = sitescope.Context(ctx, r.client, &plan.Site, &resp.Diagnostics)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+		if r.resolveZoneReferences(ctx, &plan, &resp.Diagnostics) {
+			changed = true
+		}
+		if r.resolveNetworkFilters(ctx, &plan, &resp.Diagnostics) {
+			changed = true
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if changed {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
 	}
 }
@@ -2,17 +2,25 @@ package firewall
 
 import (
 	"context"
+	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
 )
 
+// timeOfDayRegex matches a 24-hour HH:MM time of day.
+var timeOfDayRegex = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
 func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
@@ -22,6 +30,7 @@ func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.Schema
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"site": sitescope.ResourceAttribute(func() *unifi.Client { return r.client }),
 			"enabled": schema.BoolAttribute{
 				Required: true,
 			},
@@ -66,13 +75,22 @@ func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.Schema
 					},
 				},
 				Blocks: map[string]schema.Block{
+					// A stop time earlier than the start time spans midnight.
 					"time_range": schema.SingleNestedBlock{
 						Attributes: map[string]schema.Attribute{
 							"start": schema.StringAttribute{
 								Optional: true, // Workaround for validation
+								Validators: []validator.String{
+									stringvalidator.RegexMatches(timeOfDayRegex, "must be a time of day in HH:MM format"),
+									stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("stop")),
+								},
 							},
 							"stop": schema.StringAttribute{
 								Optional: true, // Workaround for validation
+								Validators: []validator.String{
+									stringvalidator.RegexMatches(timeOfDayRegex, "must be a time of day in HH:MM format"),
+									stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("start")),
+								},
 							},
 						},
 					},
@@ -98,7 +116,18 @@ func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.Schema
 			"source": schema.SingleNestedBlock{
 				Attributes: map[string]schema.Attribute{
 					"zone_id": schema.StringAttribute{
-						Required: true,
+						Optional: true,
+						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"zone": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
 				},
 				Blocks: map[string]schema.Block{
@@ -120,6 +149,9 @@ func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.Schema
 									"match_opposite": schema.BoolAttribute{
 										Optional: true, // Workaround for validation
 									},
+									"traffic_matching_list_id": schema.StringAttribute{
+										Optional: true,
+									},
 								},
 								Blocks: map[string]schema.Block{
 									"items": schema.SetNestedBlock{
@@ -154,6 +186,9 @@ func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.Schema
 										ElementType: types.StringType,
 										Optional:    true, // Workaround for validation
 									},
+									"traffic_matching_list_id": schema.StringAttribute{
+										Optional: true,
+									},
 								},
 							},
 							"mac_address_filter": schema.SingleNestedBlock{
@@ -181,6 +216,15 @@ func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.Schema
 									"items": schema.SetAttribute{
 										ElementType: types.StringType,
 										Optional:    true, // Workaround for validation
+										Computed:    true, // Resolved from names and vlan_ids
+									},
+									"names": schema.SetAttribute{
+										ElementType: types.StringType,
+										Optional:    true,
+									},
+									"vlan_ids": schema.SetAttribute{
+										ElementType: types.Int64Type,
+										Optional:    true,
 									},
 								},
 							},
@@ -192,6 +236,70 @@ func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.Schema
 									},
 								},
 							},
+							"ipv6_iid_filter": schema.SingleNestedBlock{
+								Attributes: map[string]schema.Attribute{
+									"match_opposite": schema.BoolAttribute{
+										Optional: true,
+										Computed: true,
+										Default:  booldefault.StaticBool(false),
+									},
+									"items": schema.SetAttribute{
+										ElementType: types.StringType,
+										Optional:    true, // Workaround for validation
+									},
+								},
+							},
+							"vpn_server_filter": schema.SingleNestedBlock{
+								Attributes: map[string]schema.Attribute{
+									"match_opposite": schema.BoolAttribute{
+										Optional: true,
+										Computed: true,
+										Default:  booldefault.StaticBool(false),
+									},
+									"items": schema.SetAttribute{
+										ElementType: types.StringType,
+										Optional:    true, // Workaround for validation
+									},
+								},
+							},
+							"site_to_site_tunnel_filter": schema.SingleNestedBlock{
+								Attributes: map[string]schema.Attribute{
+									"match_opposite": schema.BoolAttribute{
+										Optional: true,
+										Computed: true,
+										Default:  booldefault.StaticBool(false),
+									},
+									"items": schema.SetAttribute{
+										ElementType: types.StringType,
+										Optional:    true, // Workaround for validation
+									},
+								},
+							},
+							"region_filter": schema.SingleNestedBlock{
+								Attributes: map[string]schema.Attribute{
+									"match_opposite": schema.BoolAttribute{
+										Optional: true,
+										Computed: true,
+										Default:  booldefault.StaticBool(false),
+									},
+									"items": schema.SetAttribute{
+										ElementType: types.StringType,
+										Optional:    true, // Workaround for validation
+									},
+								},
+							},
+							"application_filter": schema.SingleNestedBlock{
+								Attributes: map[string]schema.Attribute{
+									"application_ids": schema.SetAttribute{
+										ElementType: types.Int64Type,
+										Optional:    true,
+									},
+									"category_ids": schema.SetAttribute{
+										ElementType: types.Int64Type,
+										Optional:    true,
+									},
+								},
+							},
 						},
 					},
 				},
@@ -199,7 +307,18 @@ func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.Schema
 			"destination": schema.SingleNestedBlock{
 				Attributes: map[string]schema.Attribute{
 					"zone_id": schema.StringAttribute{
-						Required: true,
+						Optional: true,
+						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"zone": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
 				},
 				Blocks: map[string]schema.Block{
@@ -221,6 +340,9 @@ func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.Schema
 									"match_opposite": schema.BoolAttribute{
 										Optional: true, // Workaround for validation
 									},
+									"traffic_matching_list_id": schema.StringAttribute{
+										Optional: true,
+									},
 								},
 								Blocks: map[string]schema.Block{
 									"items": schema.SetNestedBlock{
@@ -255,6 +377,9 @@ func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.Schema
 										ElementType: types.StringType,
 										Optional:    true, // Workaround for validation
 									},
+									"traffic_matching_list_id": schema.StringAttribute{
+										Optional: true,
+									},
 								},
 							},
 							"mac_address_filter": schema.SingleNestedBlock{
@@ -282,6 +407,15 @@ func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.Schema
 									"items": schema.SetAttribute{
 										ElementType: types.StringType,
 										Optional:    true, // Workaround for validation
+										Computed:    true, // Resolved from names and vlan_ids
+									},
+									"names": schema.SetAttribute{
+										ElementType: types.StringType,
+										Optional:    true,
+									},
+									"vlan_ids": schema.SetAttribute{
+										ElementType: types.Int64Type,
+										Optional:    true,
 									},
 								},
 							},
@@ -293,6 +427,70 @@ func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.Schema
 									},
 								},
 							},
+							"ipv6_iid_filter": schema.SingleNestedBlock{
+								Attributes: map[string]schema.Attribute{
+									"match_opposite": schema.BoolAttribute{
+										Optional: true,
+										Computed: true,
+										Default:  booldefault.StaticBool(false),
+									},
+									"items": schema.SetAttribute{
+										ElementType: types.StringType,
+										Optional:    true, // Workaround for validation
+									},
+								},
+							},
+							"vpn_server_filter": schema.SingleNestedBlock{
+								Attributes: map[string]schema.Attribute{
+									"match_opposite": schema.BoolAttribute{
+										Optional: true,
+										Computed: true,
+										Default:  booldefault.StaticBool(false),
+									},
+									"items": schema.SetAttribute{
+										ElementType: types.StringType,
+										Optional:    true, // Workaround for validation
+									},
+								},
+							},
+							"site_to_site_tunnel_filter": schema.SingleNestedBlock{
+								Attributes: map[string]schema.Attribute{
+									"match_opposite": schema.BoolAttribute{
+										Optional: true,
+										Computed: true,
+										Default:  booldefault.StaticBool(false),
+									},
+									"items": schema.SetAttribute{
+										ElementType: types.StringType,
+										Optional:    true, // Workaround for validation
+									},
+								},
+							},
+							"region_filter": schema.SingleNestedBlock{
+								Attributes: map[string]schema.Attribute{
+									"match_opposite": schema.BoolAttribute{
+										Optional: true,
+										Computed: true,
+										Default:  booldefault.StaticBool(false),
+									},
+									"items": schema.SetAttribute{
+										ElementType: types.StringType,
+										Optional:    true, // Workaround for validation
+									},
+								},
+							},
+							"application_filter": schema.SingleNestedBlock{
+								Attributes: map[string]schema.Attribute{
+									"application_ids": schema.SetAttribute{
+										ElementType: types.Int64Type,
+										Optional:    true,
+									},
+									"category_ids": schema.SetAttribute{
+										ElementType: types.Int64Type,
+										Optional:    true,
+									},
+								},
+							},
 						},
 					},
 				},
@@ -0,0 +1,503 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithValidateConfig = &FirewallPolicyResource{}
+
+// Traffic filter types accepted on each side of a policy. Domains and
+// applications can only be matched on the destination and MAC addresses only
+// on the source.
+var (
+	sourceFilterTypes      = []string{"PORT", "IP_ADDRESS", "IPV6_IID", "MAC_ADDRESS", "NETWORK", "REGION", "VPN_SERVER", "SITE_TO_SITE_VPN_TUNNEL"}
+	destinationFilterTypes = []string{"PORT", "IP_ADDRESS", "IPV6_IID", "NETWORK", "DOMAIN", "APPLICATION", "REGION", "VPN_SERVER", "SITE_TO_SITE_VPN_TUNNEL"}
+)
+
+// filterTypeBlocks maps a traffic filter type to the block that carries its
+// values.
+var filterTypeBlocks = map[string]string{
+	"PORT":                    "port_filter",
+	"IP_ADDRESS":              "ip_address_filter",
+	"IPV6_IID":                "ipv6_iid_filter",
+	"MAC_ADDRESS":             "mac_address_filter",
+	"NETWORK":                 "network_filter",
+	"DOMAIN":                  "domain_filter",
+	"APPLICATION":             "application_filter",
+	"REGION":                  "region_filter",
+	"VPN_SERVER":              "vpn_server_filter",
+	"SITE_TO_SITE_VPN_TUNNEL": "site_to_site_tunnel_filter",
+}
+
+// ValidateConfig rejects combinations the controller would refuse mid-apply,
+// such as a port filter without a TCP/UDP protocol or a domain filter on the
+// source.
+func (r *FirewallPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data FirewallPolicyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validatePolicy(data)...)
+}
+
+func validatePolicy(data FirewallPolicyResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	validateAction(data.Action, &diags)
+	validateSchedule(data.Schedule, &diags)
+
+	ipVersion := ""
+	if data.IPProtocolScope != nil && isKnown(data.IPProtocolScope.IPVersion) {
+		ipVersion = data.IPProtocolScope.IPVersion.ValueString()
+	}
+
+	validateIIDVersion(data, &diags)
+
+	for _, side := range policySides(&data) {
+		validateZoneReference(side, &diags)
+	}
+
+	hasPortFilter := false
+	if data.Source != nil && data.Source.TrafficFilter != nil {
+		validateTrafficFilter("source", data.Source.TrafficFilter, sourceFilterTypes, ipVersion, &diags)
+		hasPortFilter = hasPortFilter || data.Source.TrafficFilter.PortFilter != nil
+	}
+	if data.Destination != nil && data.Destination.TrafficFilter != nil {
+		validateTrafficFilter("destination", data.Destination.TrafficFilter, destinationFilterTypes, ipVersion, &diags)
+		hasPortFilter = hasPortFilter || data.Destination.TrafficFilter.PortFilter != nil
+	}
+	if hasPortFilter {
+		validatePortProtocol(data.IPProtocolScope, &diags)
+	}
+
+	return diags
+}
+
+// validateZoneReference checks that a source or destination names its zone
+// exactly once, by zone_id or by zone.
+func validateZoneReference(side policySide, diags *diag.Diagnostics) {
+	sd := side.sd
+	if sd == nil {
+		return
+	}
+	p := path.Root(side.name)
+	switch {
+	case !sd.ZoneID.IsNull() && !sd.Zone.IsNull():
+		diags.AddAttributeError(p.AtName("zone"), "Invalid zone",
+			"Set zone_id or zone, not both.")
+	case sd.ZoneID.IsNull() && sd.Zone.IsNull():
+		diags.AddAttributeError(p.AtName("zone_id"), "Invalid zone",
+			fmt.Sprintf("The %s needs a zone_id or a zone name.", side.name))
+	}
+}
+
+func validateAction(action *ActionModel, diags *diag.Diagnostics) {
+	if action == nil || !isKnown(action.Type) {
+		return
+	}
+	art := action.AllowReturnTraffic
+	if art.IsNull() || art.IsUnknown() || !art.ValueBool() {
+		return
+	}
+	if actionType := action.Type.ValueString(); !strings.EqualFold(actionType, "ALLOW") {
+		diags.AddAttributeError(path.Root("action").AtName("allow_return_traffic"), "Invalid action",
+			fmt.Sprintf("allow_return_traffic can only be enabled for ALLOW policies, not %s.", actionType))
+	}
+}
+
+func validateSchedule(s *FirewallScheduleModel, diags *diag.Diagnostics) {
+	if s == nil {
+		return
+	}
+	p := path.Root("schedule")
+
+	if s.Mode.IsNull() {
+		diags.AddAttributeError(p.AtName("mode"), "Invalid schedule", "mode is required when a schedule block is set.")
+		return
+	}
+	if s.Mode.IsUnknown() {
+		return
+	}
+	mode := s.Mode.ValueString()
+
+	if mode == "ONE_TIME_ONLY" {
+		if s.Start.IsNull() {
+			diags.AddAttributeError(p.AtName("start"), "Invalid schedule", "start is required for ONE_TIME_ONLY schedules.")
+		}
+		if s.Stop.IsNull() {
+			diags.AddAttributeError(p.AtName("stop"), "Invalid schedule", "stop is required for ONE_TIME_ONLY schedules.")
+		}
+		if s.TimeRange != nil {
+			diags.AddAttributeError(p.AtName("time_range"), "Invalid schedule",
+				"time_range only applies to EVERY_DAY, EVERY_WEEK and CUSTOM schedules. Use start and stop for ONE_TIME_ONLY.")
+		}
+	} else {
+		if !s.Start.IsNull() {
+			diags.AddAttributeError(p.AtName("start"), "Invalid schedule", fmt.Sprintf("start is only used with ONE_TIME_ONLY schedules, not %s.", mode))
+		}
+		if !s.Stop.IsNull() {
+			diags.AddAttributeError(p.AtName("stop"), "Invalid schedule", fmt.Sprintf("stop is only used with ONE_TIME_ONLY schedules, not %s.", mode))
+		}
+	}
+
+	if mode == "EVERY_WEEK" {
+		if s.DaysOfWeek.IsNull() || (!s.DaysOfWeek.IsUnknown() && len(s.DaysOfWeek.Elements()) == 0) {
+			diags.AddAttributeError(p.AtName("days_of_week"), "Invalid schedule", "days_of_week is required for EVERY_WEEK schedules.")
+		}
+	} else if !s.DaysOfWeek.IsNull() {
+		diags.AddAttributeError(p.AtName("days_of_week"), "Invalid schedule", fmt.Sprintf("days_of_week is only used with EVERY_WEEK schedules, not %s.", mode))
+	}
+
+	for _, day := range knownStrings(s.DaysOfWeek) {
+		if !isDayName(day) {
+			diags.AddAttributeError(p.AtName("days_of_week"), "Invalid schedule",
+				fmt.Sprintf("%q is not a day of the week. Use MON, TUE, WED, THU, FRI, SAT or SUN.", day))
+		}
+	}
+}
+
+func validateTrafficFilter(side string, tf *TrafficFilterModel, allowed []string, ipVersion string, diags *diag.Diagnostics) {
+	p := path.Root(side).AtName("traffic_filter")
+
+	if tf.Type.IsNull() {
+		diags.AddAttributeError(p.AtName("type"), "Invalid traffic filter", "type is required when a traffic_filter block is set.")
+	} else if !tf.Type.IsUnknown() {
+		filterType := tf.Type.ValueString()
+		if !slices.Contains(allowed, filterType) {
+			diags.AddAttributeError(p.AtName("type"), "Invalid traffic filter",
+				fmt.Sprintf("%s filters are not supported on the %s. Supported types: %s.", filterType, side, strings.Join(allowed, ", ")))
+		} else if block, ok := filterTypeBlocks[filterType]; ok && !hasPrimaryFilter(tf, filterType, block) {
+			diags.AddAttributeError(p.AtName(block), "Invalid traffic filter",
+				fmt.Sprintf("A %s block is required for %s filters.", block, filterType))
+		}
+	}
+
+	// Blocks for types that are not valid on this side are rejected even when
+	// they are only meant as an addition to the primary filter.
+	for _, filterType := range slices.Sorted(maps.Keys(filterTypeBlocks)) {
+		block := filterTypeBlocks[filterType]
+		if filterType == tf.Type.ValueString() || slices.Contains(allowed, filterType) {
+			continue
+		}
+		if hasFilterBlock(tf, block) {
+			diags.AddAttributeError(p.AtName(block), "Invalid traffic filter",
+				fmt.Sprintf("%s is not supported on the %s.", block, side))
+		}
+	}
+	if isKnown(tf.MACAddress) && !slices.Contains(allowed, "MAC_ADDRESS") {
+		diags.AddAttributeError(p.AtName("mac_address"), "Invalid traffic filter",
+			fmt.Sprintf("mac_address is not supported on the %s.", side))
+	}
+
+	if isKnown(tf.MACAddress) && !isMACAddress(tf.MACAddress.ValueString()) {
+		diags.AddAttributeError(p.AtName("mac_address"), "Invalid MAC address",
+			fmt.Sprintf("%q is not a valid MAC address.", tf.MACAddress.ValueString()))
+	}
+	if tf.MACAddressFilter != nil {
+		for _, mac := range knownStrings(tf.MACAddressFilter.Items) {
+			if !isMACAddress(mac) {
+				diags.AddAttributeError(p.AtName("mac_address_filter").AtName("items"), "Invalid MAC address",
+					fmt.Sprintf("%q is not a valid MAC address.", mac))
+			}
+		}
+	}
+	if tf.IPAddressFilter != nil {
+		items := tf.IPAddressFilter.Items
+		hasItems := !items.IsNull() && (items.IsUnknown() || len(items.Elements()) > 0)
+		validateListReference(p.AtName("ip_address_filter"), tf.IPAddressFilter.Type, tf.IPAddressFilter.TrafficMatchingListID, hasItems, diags)
+		for _, item := range knownStrings(items) {
+			if msg := checkIPItem(item, ipVersion); msg != "" {
+				diags.AddAttributeError(p.AtName("ip_address_filter").AtName("items"), "Invalid IP address", msg)
+			}
+		}
+	}
+	if nf := tf.NetworkFilter; nf != nil {
+		byReference := !nf.Names.IsNull() || !nf.VLANIDs.IsNull()
+		switch {
+		case byReference && !nf.Items.IsNull():
+			diags.AddAttributeError(p.AtName("network_filter").AtName("items"), "Invalid network filter",
+				"items cannot be combined with names or vlan_ids; the IDs they resolve to are filled in for you.")
+		case !byReference && nf.Items.IsNull():
+			diags.AddAttributeError(p.AtName("network_filter"), "Invalid network filter",
+				"Set items, names or vlan_ids.")
+		}
+		if !nf.VLANIDs.IsNull() && !nf.VLANIDs.IsUnknown() {
+			for _, v := range nf.VLANIDs.Elements() {
+				if id, ok := v.(types.Int64); ok && !id.IsUnknown() && (id.ValueInt64() < 1 || id.ValueInt64() > 4094) {
+					diags.AddAttributeError(p.AtName("network_filter").AtName("vlan_ids"), "Invalid VLAN ID",
+						fmt.Sprintf("%d is not a valid VLAN ID; use 1 to 4094.", id.ValueInt64()))
+				}
+			}
+		}
+	}
+	if tf.IPv6IIDFilter != nil {
+		for _, iid := range knownStrings(tf.IPv6IIDFilter.Items) {
+			if msg := checkInterfaceIdentifier(iid); msg != "" {
+				diags.AddAttributeError(p.AtName("ipv6_iid_filter").AtName("items"), "Invalid interface identifier", msg)
+			}
+		}
+	}
+	if tf.RegionFilter != nil {
+		for _, code := range knownStrings(tf.RegionFilter.Items) {
+			if msg := checkCountryCode(code); msg != "" {
+				diags.AddAttributeError(p.AtName("region_filter").AtName("items"), "Invalid region", msg)
+			}
+		}
+	}
+	if af := tf.ApplicationFilter; af != nil && !af.ApplicationIDs.IsUnknown() && !af.CategoryIDs.IsUnknown() {
+		hasApps := len(af.ApplicationIDs.Elements()) > 0
+		hasCategories := len(af.CategoryIDs.Elements()) > 0
+		if hasApps == hasCategories {
+			diags.AddAttributeError(p.AtName("application_filter"), "Invalid application filter",
+				"Set exactly one of application_ids or category_ids; use separate policies to match both.")
+		}
+	}
+	if tf.PortFilter != nil {
+		validateListReference(p.AtName("port_filter"), tf.PortFilter.Type, tf.PortFilter.TrafficMatchingListID, len(tf.PortFilter.Items) > 0, diags)
+		validatePortItems(p.AtName("port_filter").AtName("items"), tf.PortFilter.Items, diags)
+	}
+}
+
+// validateListReference checks that an IP or port filter either references a
+// traffic matching list or has inline items, and that its type says which.
+func validateListReference(p path.Path, filterType, listID types.String, hasItems bool, diags *diag.Diagnostics) {
+	if filterType.IsUnknown() || listID.IsUnknown() {
+		return
+	}
+	usesList := filterType.ValueString() == "TRAFFIC_MATCHING_LIST"
+
+	switch {
+	case usesList && listID.IsNull():
+		diags.AddAttributeError(p.AtName("traffic_matching_list_id"), "Invalid traffic filter",
+			"traffic_matching_list_id is required when type is TRAFFIC_MATCHING_LIST.")
+	case !usesList && !listID.IsNull():
+		diags.AddAttributeError(p.AtName("type"), "Invalid traffic filter",
+			"type must be TRAFFIC_MATCHING_LIST when traffic_matching_list_id is set.")
+	}
+	if usesList && hasItems {
+		diags.AddAttributeError(p.AtName("items"), "Invalid traffic filter",
+			"items cannot be combined with a traffic matching list.")
+	}
+}
+
+// hasPrimaryFilter reports whether tf configures the filter its type needs.
+// MAC_ADDRESS filters may also use the single mac_address attribute.
+func hasPrimaryFilter(tf *TrafficFilterModel, filterType, block string) bool {
+	if filterType == "MAC_ADDRESS" && !tf.MACAddress.IsNull() {
+		return true
+	}
+	return hasFilterBlock(tf, block)
+}
+
+func hasFilterBlock(tf *TrafficFilterModel, block string) bool {
+	switch block {
+	case "port_filter":
+		return tf.PortFilter != nil
+	case "ip_address_filter":
+		return tf.IPAddressFilter != nil
+	case "mac_address_filter":
+		return tf.MACAddressFilter != nil
+	case "network_filter":
+		return tf.NetworkFilter != nil
+	case "domain_filter":
+		return tf.DomainFilter != nil
+	case "application_filter":
+		return tf.ApplicationFilter != nil
+	case "region_filter":
+		return tf.RegionFilter != nil
+	case "ipv6_iid_filter":
+		return tf.IPv6IIDFilter != nil
+	case "vpn_server_filter":
+		return tf.VPNServerFilter != nil
+	case "site_to_site_tunnel_filter":
+		return tf.SiteToSiteTunnelFilter != nil
+	}
+	return false
+}
+
+func validatePortItems(p path.Path, items []PortItemModel, diags *diag.Diagnostics) {
+	for _, item := range items {
+		if item.Type.IsUnknown() {
+			continue
+		}
+		switch itemType := item.Type.ValueString(); itemType {
+		case "PORT_NUMBER":
+			if item.Value.IsNull() {
+				diags.AddAttributeError(p, "Invalid port filter", "value is required for PORT_NUMBER items.")
+			} else if !item.Value.IsUnknown() && !isPort(item.Value.ValueInt32()) {
+				diags.AddAttributeError(p, "Invalid port filter", fmt.Sprintf("Port %d is out of range 1-65535.", item.Value.ValueInt32()))
+			}
+		case "PORT_NUMBER_RANGE":
+			if item.Start.IsNull() || item.Stop.IsNull() {
+				diags.AddAttributeError(p, "Invalid port filter", "start and stop are required for PORT_NUMBER_RANGE items.")
+				continue
+			}
+			if item.Start.IsUnknown() || item.Stop.IsUnknown() {
+				continue
+			}
+			start, stop := item.Start.ValueInt32(), item.Stop.ValueInt32()
+			if !isPort(start) || !isPort(stop) {
+				diags.AddAttributeError(p, "Invalid port filter", fmt.Sprintf("Port range %d-%d is out of range 1-65535.", start, stop))
+			} else if start >= stop {
+				diags.AddAttributeError(p, "Invalid port filter", fmt.Sprintf("Port range %d-%d must have start lower than stop.", start, stop))
+			}
+		default:
+			diags.AddAttributeError(p, "Invalid port filter",
+				fmt.Sprintf("Item type %q is not supported. Use PORT_NUMBER or PORT_NUMBER_RANGE.", itemType))
+		}
+	}
+}
+
+// validatePortProtocol checks that a policy with a port filter only matches
+// TCP and/or UDP, the only protocols that have ports.
+func validatePortProtocol(scope *IPProtocolScopeModel, diags *diag.Diagnostics) {
+	p := path.Root("ip_protocol_scope").AtName("protocol_filter")
+	if scope == nil || scope.ProtocolFilter == nil || scope.ProtocolFilter.Protocol.IsNull() {
+		diags.AddAttributeError(p, "Invalid port filter",
+			"Port filters require ip_protocol_scope.protocol_filter to match TCP, UDP or TCP_UDP.")
+		return
+	}
+
+	pf := scope.ProtocolFilter
+	if pf.Protocol.IsUnknown() {
+		return
+	}
+	if !isPortProtocol(pf.Protocol.ValueString()) {
+		diags.AddAttributeError(p.AtName("protocol"), "Invalid port filter",
+			fmt.Sprintf("Port filters require a TCP, UDP or TCP_UDP protocol, not %q.", pf.Protocol.ValueString()))
+	}
+	if !pf.MatchOpposite.IsNull() && !pf.MatchOpposite.IsUnknown() && pf.MatchOpposite.ValueBool() {
+		diags.AddAttributeError(p.AtName("match_opposite"), "Invalid port filter",
+			"Port filters cannot be combined with a negated protocol filter.")
+	}
+}
+
+func isPortProtocol(protocol string) bool {
+	switch strings.ToLower(protocol) {
+	case "tcp", "udp", "tcp_udp", "6", "17":
+		return true
+	}
+	return false
+}
+
+// checkIPItem returns a description of what is wrong with an IP address,
+// subnet or range item, or "" when it is valid for the policy's IP version.
+func checkIPItem(item, ipVersion string) string {
+	entry, err := parseAddressEntry(item)
+	if err != nil {
+		return err.Error() + "."
+	}
+
+	if ipVersion == "IPV4" && !entry.Is4() {
+		return fmt.Sprintf("%q is not an IPv4 address, but ip_version is IPV4.", item)
+	}
+	if ipVersion == "IPV6" && entry.Is4() {
+		return fmt.Sprintf("%q is not an IPv6 address, but ip_version is IPV6.", item)
+	}
+	return ""
+}
+
+// validateIIDVersion rejects IPv6 interface-identifier filters on policies that
+// are not IPv6-only; an IID says nothing about IPv4 traffic.
+func validateIIDVersion(data FirewallPolicyResourceModel, diags *diag.Diagnostics) {
+	if data.IPProtocolScope != nil && data.IPProtocolScope.IPVersion.IsUnknown() {
+		return
+	}
+	ipv6 := data.IPProtocolScope != nil && data.IPProtocolScope.IPVersion.ValueString() == "IPV6"
+
+	if ipv6 {
+		return
+	}
+	for _, side := range policySides(&data) {
+		if side.sd != nil && side.sd.TrafficFilter != nil && side.sd.TrafficFilter.IPv6IIDFilter != nil {
+			diags.AddAttributeError(path.Root(side.name).AtName("traffic_filter").AtName("ipv6_iid_filter"), "Invalid traffic filter",
+				"ipv6_iid_filter requires ip_protocol_scope.ip_version = \"IPV6\".")
+		}
+	}
+}
+
+// checkInterfaceIdentifier returns a description of what is wrong with an
+// ipv6_iid_filter item, or "" when it is a valid 64-bit interface identifier.
+// Identifiers are written as the low 64 bits of an IPv6 address, either with a
+// leading "::" ("::1a2b:3c4d:5e6f:7a8b") or as four groups ("1a2b:3c4d:5e6f:7a8b").
+func checkInterfaceIdentifier(iid string) string {
+	addr := iid
+	if !strings.HasPrefix(iid, "::") && strings.Count(iid, ":") == 3 {
+		addr = "::" + iid
+	}
+
+	ip, err := netip.ParseAddr(addr)
+	if err != nil || !ip.Is6() || strings.Contains(iid, ".") || ip.Zone() != "" {
+		return fmt.Sprintf("%q is not a 64-bit interface identifier, such as \"::1a2b:3c4d:5e6f:7a8b\".", iid)
+	}
+	b := ip.As16()
+	if [8]byte(b[:8]) != [8]byte{} {
+		return fmt.Sprintf("%q sets bits of the network prefix; give only the last 64 bits, such as \"::1a2b:3c4d:5e6f:7a8b\".", iid)
+	}
+	if [8]byte(b[8:]) == [8]byte{} {
+		return fmt.Sprintf("%q is the all-zero interface identifier.", iid)
+	}
+	return ""
+}
+
+// checkCountryCode returns a description of what is wrong with a region_filter
+// item, or "" when it is an assigned ISO 3166-1 alpha-2 code.
+func checkCountryCode(code string) string {
+	if countryCodes[code] {
+		return ""
+	}
+	if upper := strings.ToUpper(code); countryCodes[upper] {
+		return fmt.Sprintf("%q must be upper case; use %q.", code, upper)
+	}
+	return fmt.Sprintf("%q is not an ISO 3166-1 alpha-2 country code, such as \"US\" or \"DE\".", code)
+}
+
+func isMACAddress(s string) bool {
+	hw, err := net.ParseMAC(s)
+	return err == nil && len(hw) == 6
+}
+
+func isPort(v int32) bool {
+	return v >= 1 && v <= 65535
+}
+
+func isDayName(day string) bool {
+	switch day {
+	case "MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN",
+		"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY":
+		return true
+	}
+	return false
+}
+
+func isKnown(v types.String) bool {
+	return !v.IsNull() && !v.IsUnknown() && v.ValueString() != ""
+}
+
+// knownStrings returns the known elements of a string set, skipping unknown
+// ones so that validation can run before all values are computed.
+func knownStrings(set types.Set) []string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+	var out []string
+	for _, v := range set.Elements() {
+		if s, ok := v.(types.String); ok && !s.IsNull() && !s.IsUnknown() {
+			out = append(out, s.ValueString())
+		}
+	}
+	return out
+}
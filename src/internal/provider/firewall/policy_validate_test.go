@@ -0,0 +1,441 @@
+package firewall
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func stringSet(values ...string) types.Set {
+	set, _ := types.SetValueFrom(context.Background(), types.StringType, values)
+	return set
+}
+
+func tcpProtocolScope(ipVersion string) *IPProtocolScopeModel {
+	return &IPProtocolScopeModel{
+		IPVersion: types.StringValue(ipVersion),
+		ProtocolFilter: &ProtocolFilterModel{
+			Type:          types.StringValue("PROTOCOL"),
+			Protocol:      types.StringValue("tcp"),
+			MatchOpposite: types.BoolValue(false),
+		},
+	}
+}
+
+func portFilter(items ...PortItemModel) *PortFilterModel {
+	return &PortFilterModel{
+		Type:          types.StringValue("PORTS"),
+		MatchOpposite: types.BoolValue(false),
+		Items:         items,
+	}
+}
+
+func portNumber(v int32) PortItemModel {
+	return PortItemModel{Type: types.StringValue("PORT_NUMBER"), Value: types.Int32Value(v)}
+}
+
+func portRange(start, stop int32) PortItemModel {
+	return PortItemModel{Type: types.StringValue("PORT_NUMBER_RANGE"), Start: types.Int32Value(start), Stop: types.Int32Value(stop)}
+}
+
+// errorAt returns the summary and detail of the first error reported at the
+// given attribute path.
+func errorAt(diags diag.Diagnostics, attrPath string) (string, bool) {
+	for _, d := range diags.Errors() {
+		if wp, ok := d.(diag.DiagnosticWithPath); ok && wp.Path().String() == attrPath {
+			return d.Summary() + ": " + d.Detail(), true
+		}
+	}
+	return "", false
+}
+
+func TestValidatePolicy_Valid(t *testing.T) {
+	data := minimalTFModel()
+	data.IPProtocolScope = tcpProtocolScope("IPV4")
+	data.Source.TrafficFilter = &TrafficFilterModel{
+		Type:       types.StringValue("MAC_ADDRESS"),
+		MACAddress: types.StringNull(),
+		MACAddressFilter: &MACAddressFilterModel{
+			Items: stringSet("00:11:22:33:44:55"),
+		},
+	}
+	data.Destination.TrafficFilter = &TrafficFilterModel{
+		Type:       types.StringValue("IP_ADDRESS"),
+		MACAddress: types.StringNull(),
+		IPAddressFilter: &IPAddressFilterModel{
+			Items: stringSet("192.168.1.10", "10.0.0.0/8"),
+		},
+		PortFilter: portFilter(portNumber(443), portRange(8000, 8080)),
+	}
+	data.Schedule = &FirewallScheduleModel{
+		Mode:       types.StringValue("EVERY_WEEK"),
+		DaysOfWeek: stringSet("MON", "FRI"),
+		TimeRange:  &TimeRangeModel{Start: types.StringValue("22:00"), Stop: types.StringValue("07:00")},
+	}
+
+	if diags := validatePolicy(data); diags.HasError() {
+		t.Fatalf("expected no errors, got %v", diags)
+	}
+}
+
+func TestValidatePolicy_FilterTypePerSide(t *testing.T) {
+	data := minimalTFModel()
+	data.Source.TrafficFilter = &TrafficFilterModel{
+		Type:         types.StringValue("DOMAIN"),
+		DomainFilter: &DomainFilterModel{Items: stringSet("example.com")},
+	}
+	data.Destination.TrafficFilter = &TrafficFilterModel{
+		Type:             types.StringValue("NETWORK"),
+		NetworkFilter:    &NetworkFilterModel{Items: stringSet("net-1")},
+		MACAddressFilter: &MACAddressFilterModel{Items: stringSet("00:11:22:33:44:55")},
+	}
+
+	diags := validatePolicy(data)
+
+	if msg, ok := errorAt(diags, "source.traffic_filter.type"); !ok || !strings.Contains(msg, "DOMAIN") {
+		t.Errorf("expected DOMAIN to be rejected on the source, got %v", diags)
+	}
+	if _, ok := errorAt(diags, "destination.traffic_filter.mac_address_filter"); !ok {
+		t.Errorf("expected mac_address_filter to be rejected on the destination, got %v", diags)
+	}
+}
+
+func TestValidatePolicy_MissingFilterBlock(t *testing.T) {
+	data := minimalTFModel()
+	data.Destination.TrafficFilter = &TrafficFilterModel{Type: types.StringValue("NETWORK")}
+
+	if _, ok := errorAt(validatePolicy(data), "destination.traffic_filter.network_filter"); !ok {
+		t.Error("expected an error for a NETWORK filter without network_filter")
+	}
+}
+
+func TestValidatePolicy_ApplicationFilter(t *testing.T) {
+	ids := func(values ...int64) types.Set {
+		set, _ := types.SetValueFrom(context.Background(), types.Int64Type, values)
+		return set
+	}
+	cases := []struct {
+		name   string
+		filter *ApplicationFilterModel
+		valid  bool
+	}{
+		{"applications", &ApplicationFilterModel{ApplicationIDs: ids(65539), CategoryIDs: types.SetNull(types.Int64Type)}, true},
+		{"categories", &ApplicationFilterModel{ApplicationIDs: types.SetNull(types.Int64Type), CategoryIDs: ids(24)}, true},
+		{"both", &ApplicationFilterModel{ApplicationIDs: ids(65539), CategoryIDs: ids(24)}, false},
+		{"neither", &ApplicationFilterModel{ApplicationIDs: types.SetNull(types.Int64Type), CategoryIDs: types.SetNull(types.Int64Type)}, false},
+	}
+	for _, tc := range cases {
+		data := minimalTFModel()
+		data.Destination.TrafficFilter = &TrafficFilterModel{Type: types.StringValue("APPLICATION"), ApplicationFilter: tc.filter}
+		_, hasErr := errorAt(validatePolicy(data), "destination.traffic_filter.application_filter")
+		if hasErr == tc.valid {
+			t.Errorf("%s: expected valid=%v", tc.name, tc.valid)
+		}
+	}
+
+	data := minimalTFModel()
+	data.Source.TrafficFilter = &TrafficFilterModel{
+		Type:              types.StringValue("APPLICATION"),
+		ApplicationFilter: &ApplicationFilterModel{ApplicationIDs: ids(65539), CategoryIDs: types.SetNull(types.Int64Type)},
+	}
+	if _, ok := errorAt(validatePolicy(data), "source.traffic_filter.type"); !ok {
+		t.Error("expected APPLICATION to be rejected on the source")
+	}
+}
+
+func TestValidatePolicy_RegionCodes(t *testing.T) {
+	cases := []struct {
+		code  string
+		valid bool
+	}{
+		{"US", true},
+		{"DE", true},
+		{"AX", true},
+		{"de", false},
+		{"UK", false},
+		{"USA", false},
+		{"", false},
+	}
+	for _, tc := range cases {
+		data := minimalTFModel()
+		data.Source.TrafficFilter = &TrafficFilterModel{
+			Type:         types.StringValue("REGION"),
+			RegionFilter: &RegionFilterModel{MatchOpposite: types.BoolValue(true), Items: stringSet(tc.code)},
+		}
+		msg, hasErr := errorAt(validatePolicy(data), "source.traffic_filter.region_filter.items")
+		if hasErr == tc.valid {
+			t.Errorf("%q: expected valid=%v, got %q", tc.code, tc.valid, msg)
+		}
+	}
+
+	if msg := checkCountryCode("de"); !strings.Contains(msg, `"DE"`) {
+		t.Errorf("expected a hint to use upper case, got %q", msg)
+	}
+}
+
+func TestValidatePolicy_IPv6IIDFilter(t *testing.T) {
+	cases := []struct {
+		iid   string
+		valid bool
+	}{
+		{"::1a2b:3c4d:5e6f:7a8b", true},
+		{"1a2b:3c4d:5e6f:7a8b", true},
+		{"::1", true},
+		{"::ABCD:ef01:0:1", true},
+		{"2001:db8::1", false},
+		{"::", false},
+		{"1a2b:3c4d:5e6f", false},
+		{"::ffff:10.0.0.1", false},
+		{"fe80::1%eth0", false},
+		{"not-an-iid", false},
+	}
+	for _, tc := range cases {
+		data := minimalTFModel()
+		data.IPProtocolScope = &IPProtocolScopeModel{IPVersion: types.StringValue("IPV6")}
+		data.Source.TrafficFilter = &TrafficFilterModel{
+			Type:          types.StringValue("IPV6_IID"),
+			IPv6IIDFilter: &IPv6IIDFilterModel{MatchOpposite: types.BoolValue(false), Items: stringSet(tc.iid)},
+		}
+		msg, hasErr := errorAt(validatePolicy(data), "source.traffic_filter.ipv6_iid_filter.items")
+		if hasErr == tc.valid {
+			t.Errorf("%q: expected valid=%v, got %q", tc.iid, tc.valid, msg)
+		}
+	}
+
+	for _, ipVersion := range []string{"IPV4", "IPV4_AND_IPV6"} {
+		data := minimalTFModel()
+		data.IPProtocolScope = &IPProtocolScopeModel{IPVersion: types.StringValue(ipVersion)}
+		data.Destination.TrafficFilter = &TrafficFilterModel{
+			Type:          types.StringValue("IPV6_IID"),
+			IPv6IIDFilter: &IPv6IIDFilterModel{MatchOpposite: types.BoolValue(false), Items: stringSet("::1")},
+		}
+		if _, ok := errorAt(validatePolicy(data), "destination.traffic_filter.ipv6_iid_filter"); !ok {
+			t.Errorf("%s: expected ipv6_iid_filter to require IPV6", ipVersion)
+		}
+	}
+}
+
+func TestValidatePolicy_PortFilterRequiresTCPOrUDP(t *testing.T) {
+	withPorts := func() FirewallPolicyResourceModel {
+		data := minimalTFModel()
+		data.Destination.TrafficFilter = &TrafficFilterModel{
+			Type:       types.StringValue("PORT"),
+			PortFilter: portFilter(portNumber(22)),
+		}
+		return data
+	}
+
+	data := withPorts()
+	if _, ok := errorAt(validatePolicy(data), "ip_protocol_scope.protocol_filter"); !ok {
+		t.Error("expected an error for a port filter without a protocol filter")
+	}
+
+	data = withPorts()
+	data.IPProtocolScope = tcpProtocolScope("IPV4")
+	data.IPProtocolScope.ProtocolFilter.Protocol = types.StringValue("icmp")
+	if _, ok := errorAt(validatePolicy(data), "ip_protocol_scope.protocol_filter.protocol"); !ok {
+		t.Error("expected an error for a port filter with ICMP")
+	}
+
+	data = withPorts()
+	data.IPProtocolScope = tcpProtocolScope("IPV4")
+	data.IPProtocolScope.ProtocolFilter.MatchOpposite = types.BoolValue(true)
+	if _, ok := errorAt(validatePolicy(data), "ip_protocol_scope.protocol_filter.match_opposite"); !ok {
+		t.Error("expected an error for a port filter with a negated protocol")
+	}
+
+	for _, protocol := range []string{"tcp", "UDP", "tcp_udp"} {
+		data = withPorts()
+		data.IPProtocolScope = tcpProtocolScope("IPV4")
+		data.IPProtocolScope.ProtocolFilter.Protocol = types.StringValue(protocol)
+		if diags := validatePolicy(data); diags.HasError() {
+			t.Errorf("%s: expected no errors, got %v", protocol, diags)
+		}
+	}
+}
+
+func TestValidatePolicy_PortItems(t *testing.T) {
+	cases := map[string]PortItemModel{
+		"out of range":   portNumber(70000),
+		"zero":           portNumber(0),
+		"missing value":  {Type: types.StringValue("PORT_NUMBER")},
+		"reversed range": portRange(9000, 8000),
+		"empty range":    portRange(8000, 8000),
+		"range too high": portRange(1, 65536),
+		"missing stop":   {Type: types.StringValue("PORT_NUMBER_RANGE"), Start: types.Int32Value(1)},
+		"unknown type":   {Type: types.StringValue("PORT_LIST"), Value: types.Int32Value(22)},
+	}
+	for name, item := range cases {
+		data := minimalTFModel()
+		data.IPProtocolScope = tcpProtocolScope("IPV4")
+		data.Destination.TrafficFilter = &TrafficFilterModel{
+			Type:       types.StringValue("PORT"),
+			PortFilter: portFilter(item),
+		}
+		if _, ok := errorAt(validatePolicy(data), "destination.traffic_filter.port_filter.items"); !ok {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestValidatePolicy_IPItemsMatchIPVersion(t *testing.T) {
+	cases := []struct {
+		ipVersion string
+		item      string
+		valid     bool
+	}{
+		{"IPV4", "192.168.1.1", true},
+		{"IPV4", "192.168.0.0/16", true},
+		{"IPV4", "2001:db8::1", false},
+		{"IPV6", "2001:db8::/32", true},
+		{"IPV6", "10.0.0.1", false},
+		{"IPV4_AND_IPV6", "10.0.0.1", true},
+		{"IPV4_AND_IPV6", "2001:db8::1", true},
+		{"IPV4", "192.168.1.300", false},
+		{"IPV4", "10.0.0.0/33", false},
+		{"IPV4", "example.com", false},
+		{"IPV4", "10.0.0.10-10.0.0.50", true},
+		{"IPV4", "10.0.0.50-10.0.0.10", false},
+		{"IPV4", "fd00::1-fd00::ff", false},
+		{"IPV6", "fd00::1-fd00::ff", true},
+		{"IPV6", "2001:db8::1/128", true},
+	}
+	for _, tc := range cases {
+		data := minimalTFModel()
+		data.IPProtocolScope.IPVersion = types.StringValue(tc.ipVersion)
+		data.Source.TrafficFilter = &TrafficFilterModel{
+			Type:            types.StringValue("IP_ADDRESS"),
+			IPAddressFilter: &IPAddressFilterModel{Items: stringSet(tc.item)},
+		}
+		_, hasErr := errorAt(validatePolicy(data), "source.traffic_filter.ip_address_filter.items")
+		if hasErr == tc.valid {
+			t.Errorf("%s %s: expected valid=%v", tc.ipVersion, tc.item, tc.valid)
+		}
+	}
+}
+
+func TestValidatePolicy_MACAddresses(t *testing.T) {
+	data := minimalTFModel()
+	data.Source.TrafficFilter = &TrafficFilterModel{
+		Type:             types.StringValue("MAC_ADDRESS"),
+		MACAddressFilter: &MACAddressFilterModel{Items: stringSet("00:11:22:33:44:55", "not-a-mac")},
+	}
+
+	msg, ok := errorAt(validatePolicy(data), "source.traffic_filter.mac_address_filter.items")
+	if !ok || !strings.Contains(msg, "not-a-mac") {
+		t.Errorf("expected an error naming the invalid MAC, got %q", msg)
+	}
+}
+
+func TestValidatePolicy_MACAddressAttribute(t *testing.T) {
+	data := minimalTFModel()
+	data.Source.TrafficFilter = &TrafficFilterModel{
+		Type:       types.StringValue("MAC_ADDRESS"),
+		MACAddress: types.StringValue("00:11:22:33:44:55"),
+	}
+	if diags := validatePolicy(data); diags.HasError() {
+		t.Errorf("expected mac_address to satisfy MAC_ADDRESS, got %v", diags)
+	}
+
+	data.Source.TrafficFilter.MACAddress = types.StringValue("not-a-mac")
+	if _, ok := errorAt(validatePolicy(data), "source.traffic_filter.mac_address"); !ok {
+		t.Error("expected an invalid mac_address to be rejected")
+	}
+}
+
+func TestValidatePolicy_AllowReturnTraffic(t *testing.T) {
+	data := minimalTFModel()
+	data.Action.Type = types.StringValue("BLOCK")
+
+	if _, ok := errorAt(validatePolicy(data), "action.allow_return_traffic"); !ok {
+		t.Error("expected an error for allow_return_traffic on BLOCK")
+	}
+
+	data.Action.AllowReturnTraffic = types.BoolValue(false)
+	if diags := validatePolicy(data); diags.HasError() {
+		t.Errorf("expected no errors, got %v", diags)
+	}
+}
+
+func TestValidatePolicy_Schedule(t *testing.T) {
+	cases := []struct {
+		name     string
+		schedule FirewallScheduleModel
+		attr     string
+	}{
+		{"missing mode", FirewallScheduleModel{}, "schedule.mode"},
+		{"one time without start", FirewallScheduleModel{Mode: types.StringValue("ONE_TIME_ONLY"), Stop: types.StringValue("2025-01-02T00:00:00Z")}, "schedule.start"},
+		{"one time without stop", FirewallScheduleModel{Mode: types.StringValue("ONE_TIME_ONLY"), Start: types.StringValue("2025-01-01T00:00:00Z")}, "schedule.stop"},
+		{"one time with time range", FirewallScheduleModel{
+			Mode:      types.StringValue("ONE_TIME_ONLY"),
+			Start:     types.StringValue("2025-01-01T00:00:00Z"),
+			Stop:      types.StringValue("2025-01-02T00:00:00Z"),
+			TimeRange: &TimeRangeModel{Start: types.StringValue("08:00"), Stop: types.StringValue("17:00")},
+		}, "schedule.time_range"},
+		{"every week without days", FirewallScheduleModel{Mode: types.StringValue("EVERY_WEEK")}, "schedule.days_of_week"},
+		{"every day with days", FirewallScheduleModel{Mode: types.StringValue("EVERY_DAY"), DaysOfWeek: stringSet("MON")}, "schedule.days_of_week"},
+		{"every day with start", FirewallScheduleModel{Mode: types.StringValue("EVERY_DAY"), Start: types.StringValue("2025-01-01T00:00:00Z")}, "schedule.start"},
+		{"invalid day", FirewallScheduleModel{Mode: types.StringValue("EVERY_WEEK"), DaysOfWeek: stringSet("MON", "FUNDAY")}, "schedule.days_of_week"},
+	}
+	for _, tc := range cases {
+		data := minimalTFModel()
+		schedule := tc.schedule
+		data.Schedule = &schedule
+		if _, ok := errorAt(validatePolicy(data), tc.attr); !ok {
+			t.Errorf("%s: expected an error at %s", tc.name, tc.attr)
+		}
+	}
+
+	data := minimalTFModel()
+	data.Schedule = &FirewallScheduleModel{
+		Mode:  types.StringValue("ONE_TIME_ONLY"),
+		Start: types.StringValue("2025-01-01T00:00:00Z"),
+		Stop:  types.StringValue("2025-01-02T00:00:00Z"),
+	}
+	if diags := validatePolicy(data); diags.HasError() {
+		t.Errorf("expected valid ONE_TIME_ONLY schedule, got %v", diags)
+	}
+}
+
+func TestValidatePolicy_UnknownValuesSkipped(t *testing.T) {
+	data := minimalTFModel()
+	data.IPProtocolScope = tcpProtocolScope("IPV4")
+	data.IPProtocolScope.ProtocolFilter.Protocol = types.StringUnknown()
+	data.Source.TrafficFilter = &TrafficFilterModel{
+		Type:            types.StringUnknown(),
+		IPAddressFilter: &IPAddressFilterModel{Items: types.SetUnknown(types.StringType)},
+		PortFilter:      portFilter(PortItemModel{Type: types.StringValue("PORT_NUMBER"), Value: types.Int32Unknown()}),
+	}
+	data.Schedule = &FirewallScheduleModel{Mode: types.StringUnknown()}
+
+	if diags := validatePolicy(data); diags.HasError() {
+		t.Errorf("expected unknown values to be skipped, got %v", diags)
+	}
+}
+
+// TestExamples_PortFiltersHaveProtocol keeps the shipped examples in line with
+// validatePortProtocol: every unifi_fw with a port_filter sets a TCP or UDP
+// protocol_filter.
+func TestExamples_PortFiltersHaveProtocol(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "examples", "firewall_policies.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks := strings.Split(string(content), "\nresource ")
+	for _, block := range blocks[1:] {
+		name, _, _ := strings.Cut(block, " {")
+		if !strings.HasPrefix(name, `"unifi_fw"`) || !strings.Contains(block, "port_filter {") {
+			continue
+		}
+		protocol := regexp.MustCompile(`protocol\s*=\s*"(\w+)"`).FindStringSubmatch(block)
+		if protocol == nil || !isPortProtocol(protocol[1]) {
+			t.Errorf("%s: a port_filter needs a TCP or UDP protocol_filter", name)
+		}
+	}
+}
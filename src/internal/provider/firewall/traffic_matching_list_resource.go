@@ -0,0 +1,339 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var (
+	_ resource.Resource                   = &TrafficMatchingListResource{}
+	_ resource.ResourceWithConfigure      = &TrafficMatchingListResource{}
+	_ resource.ResourceWithImportState    = &TrafficMatchingListResource{}
+	_ resource.ResourceWithValidateConfig = &TrafficMatchingListResource{}
+)
+
+func NewTrafficMatchingListResource() resource.Resource {
+	return &TrafficMatchingListResource{}
+}
+
+// TrafficMatchingListResource manages a reusable list of IP addresses or
+// ports that firewall policies can reference instead of inline items.
+type TrafficMatchingListResource struct {
+	client *unifi.Client
+}
+
+type TrafficMatchingListResourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Name  types.String `tfsdk:"name"`
+	Type  types.String `tfsdk:"type"`
+	Items types.Set    `tfsdk:"items"`
+	Site  types.String `tfsdk:"site"`
+}
+
+func (r *TrafficMatchingListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_traffic_matching_list"
+}
+
+func (r *TrafficMatchingListResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A reusable list of IP addresses or ports. Reference it from a `unifi_fw` `ip_address_filter` or `port_filter` " +
+			"with `type = \"TRAFFIC_MATCHING_LIST\"` and `traffic_matching_list_id`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the list.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"site": sitescope.ResourceAttribute(func() *unifi.Client { return r.client }),
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the list.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The kind of entries in the list: `PORTS`, `IPV4_ADDRESSES` or `IPV6_ADDRESSES`. Changing it forces a new list.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("PORTS", "IPV4_ADDRESSES", "IPV6_ADDRESSES"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"items": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				MarkdownDescription: "The entries. Ports are given as `443` or as a range `8000-8080`; " +
+					"addresses as an IP (`10.0.0.1`), a subnet (`10.0.0.0/8`) or a range (`10.0.0.10-10.0.0.50`).",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *TrafficMatchingListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*unifi.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", "Expected *unifi.Client")
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks every entry against the list type.
+func (r *TrafficMatchingListResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TrafficMatchingListResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !isKnown(data.Type) {
+		return
+	}
+
+	for _, item := range knownStrings(data.Items) {
+		if _, err := parseTrafficMatchingListItem(data.Type.ValueString(), item); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("items"), "Invalid list entry", err.Error())
+		}
+	}
+}
+
+func (r *TrafficMatchingListResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TrafficMatchingListResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &plan.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, diags := trafficMatchingListFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateTrafficMatchingList(ctx, list)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating traffic matching list", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(created.ID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TrafficMatchingListResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TrafficMatchingListResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &state.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, err := r.client.GetTrafficMatchingList(ctx, state.ID.ValueString())
+	if err != nil {
+		if unifi.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading traffic matching list", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(trafficMatchingListToModel(ctx, list, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *TrafficMatchingListResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan TrafficMatchingListResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &plan.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, diags := trafficMatchingListFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.UpdateTrafficMatchingList(ctx, plan.ID.ValueString(), list); err != nil {
+		resp.Diagnostics.AddError("Error updating traffic matching list", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TrafficMatchingListResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TrafficMatchingListResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &state.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteTrafficMatchingList(ctx, state.ID.ValueString())
+	if err != nil && !unifi.IsNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting traffic matching list", err.Error())
+		return
+	}
+}
+
+// ImportState accepts <id>, or <site>/<id> for an object of another site.
+func (r *TrafficMatchingListResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id := sitescope.ImportContext(ctx, r.client, req.ID, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func trafficMatchingListFromModel(ctx context.Context, m TrafficMatchingListResourceModel) (unifi.TrafficMatchingList, diag.Diagnostics) {
+	list := unifi.TrafficMatchingList{
+		Name: m.Name.ValueString(),
+		Type: m.Type.ValueString(),
+	}
+
+	var entries []string
+	diags := m.Items.ElementsAs(ctx, &entries, false)
+	if diags.HasError() {
+		return list, diags
+	}
+	sort.Strings(entries)
+
+	for _, entry := range entries {
+		item, err := parseTrafficMatchingListItem(list.Type, entry)
+		if err != nil {
+			diags.AddAttributeError(path.Root("items"), "Invalid list entry", err.Error())
+			continue
+		}
+		list.Items = append(list.Items, item)
+	}
+	return list, diags
+}
+
+func trafficMatchingListToModel(ctx context.Context, list *unifi.TrafficMatchingList, m *TrafficMatchingListResourceModel) diag.Diagnostics {
+	m.ID = types.StringValue(list.ID)
+	m.Name = types.StringValue(list.Name)
+	m.Type = types.StringValue(list.Type)
+
+	entries := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		entries = append(entries, formatTrafficMatchingListItem(item))
+	}
+	if list.Type != "PORTS" {
+		entries = preferConfiguredSpelling(entries, knownStrings(m.Items), canonicalAddressEntry)
+	}
+
+	items, diags := types.SetValueFrom(ctx, types.StringType, entries)
+	m.Items = items
+	return diags
+}
+
+// parseTrafficMatchingListItem converts an entry such as "443", "8000-8080",
+// "10.0.0.1", "10.0.0.0/8" or "10.0.0.10-10.0.0.50" into its API form.
+func parseTrafficMatchingListItem(listType, raw string) (unifi.TrafficMatchingListItem, error) {
+	if listType == "PORTS" {
+		if start, stop, ok := strings.Cut(raw, "-"); ok {
+			from, errFrom := strconv.Atoi(start)
+			to, errTo := strconv.Atoi(stop)
+			if errFrom != nil || errTo != nil || !isPort(int32(from)) || !isPort(int32(to)) || from >= to {
+				return unifi.TrafficMatchingListItem{}, fmt.Errorf("%q is not a valid port range; use <start>-<stop> with 1 <= start < stop <= 65535", raw)
+			}
+			return unifi.TrafficMatchingListItem{Type: "PORT_NUMBER_RANGE", Start: from, Stop: to}, nil
+		}
+		port, err := strconv.Atoi(raw)
+		if err != nil || !isPort(int32(port)) {
+			return unifi.TrafficMatchingListItem{}, fmt.Errorf("%q is not a valid port; use a number between 1 and 65535", raw)
+		}
+		return unifi.TrafficMatchingListItem{Type: "PORT_NUMBER", Value: port}, nil
+	}
+
+	entry, err := parseAddressEntry(raw)
+	if err != nil {
+		return unifi.TrafficMatchingListItem{}, err
+	}
+	if wantV4 := listType == "IPV4_ADDRESSES"; wantV4 != entry.Is4() {
+		return unifi.TrafficMatchingListItem{}, fmt.Errorf("%q does not match the list type %s", raw, listType)
+	}
+
+	item := unifi.TrafficMatchingListItem{Type: entry.Type}
+	if entry.Type == "IP_ADDRESS_RANGE" {
+		item.Start, item.Stop = entry.Start, entry.Stop
+	} else {
+		item.Value = entry.Value
+	}
+	return item, nil
+}
+
+// formatTrafficMatchingListItem is the inverse of parseTrafficMatchingListItem.
+func formatTrafficMatchingListItem(item unifi.TrafficMatchingListItem) string {
+	switch item.Type {
+	case "PORT_NUMBER_RANGE", "IP_ADDRESS_RANGE":
+		return listValueString(item.Start) + "-" + listValueString(item.Stop)
+	}
+	return listValueString(item.Value)
+}
+
+// listValueString renders a polymorphic item value. Ports decode from JSON as
+// float64, addresses as strings.
+func listValueString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,172 @@
+package firewall
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+func TestParseTrafficMatchingListItem(t *testing.T) {
+	tests := []struct {
+		listType string
+		entry    string
+		wantType string
+	}{
+		{"PORTS", "443", "PORT_NUMBER"},
+		{"PORTS", "8000-8080", "PORT_NUMBER_RANGE"},
+		{"IPV4_ADDRESSES", "10.0.0.1", "IP_ADDRESS"},
+		{"IPV4_ADDRESSES", "10.0.0.0/8", "SUBNET"},
+		{"IPV4_ADDRESSES", "10.0.0.10-10.0.0.50", "IP_ADDRESS_RANGE"},
+		{"IPV6_ADDRESSES", "2001:db8::1", "IP_ADDRESS"},
+		{"IPV6_ADDRESSES", "2001:db8::/32", "SUBNET"},
+		{"IPV6_ADDRESSES", "2001:db8::1-2001:db8::ff", "IP_ADDRESS_RANGE"},
+	}
+	for _, tt := range tests {
+		item, err := parseTrafficMatchingListItem(tt.listType, tt.entry)
+		if err != nil {
+			t.Errorf("%s %q: unexpected error: %v", tt.listType, tt.entry, err)
+			continue
+		}
+		if item.Type != tt.wantType {
+			t.Errorf("%s %q: type = %q, want %q", tt.listType, tt.entry, item.Type, tt.wantType)
+		}
+	}
+}
+
+func TestParseTrafficMatchingListItem_Invalid(t *testing.T) {
+	tests := []struct {
+		listType string
+		entry    string
+	}{
+		{"PORTS", "0"},
+		{"PORTS", "65536"},
+		{"PORTS", "http"},
+		{"PORTS", "8080-8000"},
+		{"PORTS", "80 - 90"},
+		{"IPV4_ADDRESSES", "2001:db8::1"},
+		{"IPV4_ADDRESSES", "10.0.0.0/33"},
+		{"IPV4_ADDRESSES", "10.0.0.50-10.0.0.10"},
+		{"IPV4_ADDRESSES", "10.0.0.1-2001:db8::1"},
+		{"IPV6_ADDRESSES", "10.0.0.1"},
+		{"IPV6_ADDRESSES", "example.com"},
+	}
+	for _, tt := range tests {
+		if _, err := parseTrafficMatchingListItem(tt.listType, tt.entry); err == nil {
+			t.Errorf("%s %q: expected an error", tt.listType, tt.entry)
+		}
+	}
+}
+
+func TestTrafficMatchingList_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	for listType, entries := range map[string][]string{
+		"PORTS":          {"22", "443", "8000-8080"},
+		"IPV4_ADDRESSES": {"10.0.0.0/8", "192.168.1.1", "192.168.1.10-192.168.1.20"},
+		"IPV6_ADDRESSES": {"2001:db8::/32", "fd00::1"},
+	} {
+		plan := TrafficMatchingListResourceModel{
+			Name:  types.StringValue("test"),
+			Type:  types.StringValue(listType),
+			Items: stringSet(entries...),
+		}
+
+		list, diags := trafficMatchingListFromModel(ctx, plan)
+		if diags.HasError() {
+			t.Fatalf("%s: trafficMatchingListFromModel: %v", listType, diags)
+		}
+
+		// Send it through JSON so ports come back as float64, as they do
+		// from the controller.
+		body, _ := json.Marshal(list)
+		var decoded unifi.TrafficMatchingList
+		if err := json.Unmarshal(body, &decoded); err != nil {
+			t.Fatal(err)
+		}
+
+		var state TrafficMatchingListResourceModel
+		if diags := trafficMatchingListToModel(ctx, &decoded, &state); diags.HasError() {
+			t.Fatalf("%s: trafficMatchingListToModel: %v", listType, diags)
+		}
+		if !state.Items.Equal(plan.Items) {
+			t.Errorf("%s: items drifted: got %v, want %v", listType, state.Items, plan.Items)
+		}
+	}
+}
+
+func TestTrafficFilter_ListReferenceRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	tf := &TrafficFilterModel{
+		Type: types.StringValue("IP_ADDRESS"),
+		IPAddressFilter: &IPAddressFilterModel{
+			Type:                  types.StringValue("TRAFFIC_MATCHING_LIST"),
+			MatchOpposite:         types.BoolValue(true),
+			Items:                 types.SetNull(types.StringType),
+			TrafficMatchingListID: types.StringValue("list-ip"),
+		},
+		PortFilter: &PortFilterModel{
+			Type:                  types.StringValue("TRAFFIC_MATCHING_LIST"),
+			MatchOpposite:         types.BoolValue(false),
+			TrafficMatchingListID: types.StringValue("list-ports"),
+		},
+	}
+
+	apiTF := mapTrafficFilterToAPI(ctx, tf)
+	if apiTF.IPAddressFilter.TrafficMatchingListID != "list-ip" || len(apiTF.IPAddressFilter.Items) != 0 {
+		t.Errorf("unexpected IP filter: %+v", apiTF.IPAddressFilter)
+	}
+	if apiTF.PortFilter.TrafficMatchingListID != "list-ports" || len(apiTF.PortFilter.Items) != 0 {
+		t.Errorf("unexpected port filter: %+v", apiTF.PortFilter)
+	}
+
+	got := mapTrafficFilterFromAPI(ctx, apiTF)
+	if got == nil || got.IPAddressFilter == nil || got.PortFilter == nil {
+		t.Fatalf("expected IP and port filters after round trip, got %+v", got)
+	}
+	if got.IPAddressFilter.TrafficMatchingListID.ValueString() != "list-ip" || !got.IPAddressFilter.Items.IsNull() {
+		t.Errorf("unexpected IP filter model: %+v", got.IPAddressFilter)
+	}
+	if !got.IPAddressFilter.MatchOpposite.ValueBool() {
+		t.Error("expected match_opposite to survive the round trip")
+	}
+	if got.PortFilter.TrafficMatchingListID.ValueString() != "list-ports" || got.PortFilter.Type.ValueString() != "TRAFFIC_MATCHING_LIST" {
+		t.Errorf("unexpected port filter model: %+v", got.PortFilter)
+	}
+}
+
+func TestValidatePolicy_ListReference(t *testing.T) {
+	cases := []struct {
+		name   string
+		filter *IPAddressFilterModel
+		attr   string
+	}{
+		{"list type without id", &IPAddressFilterModel{Type: types.StringValue("TRAFFIC_MATCHING_LIST")},
+			"source.traffic_filter.ip_address_filter.traffic_matching_list_id"},
+		{"id with inline type", &IPAddressFilterModel{Type: types.StringValue("IP_ADDRESSES"), TrafficMatchingListID: types.StringValue("list-1")},
+			"source.traffic_filter.ip_address_filter.type"},
+		{"list with items", &IPAddressFilterModel{Type: types.StringValue("TRAFFIC_MATCHING_LIST"), TrafficMatchingListID: types.StringValue("list-1"), Items: stringSet("10.0.0.1")},
+			"source.traffic_filter.ip_address_filter.items"},
+	}
+	for _, tc := range cases {
+		data := minimalTFModel()
+		data.Source.TrafficFilter = &TrafficFilterModel{Type: types.StringValue("IP_ADDRESS"), IPAddressFilter: tc.filter}
+		if _, ok := errorAt(validatePolicy(data), tc.attr); !ok {
+			t.Errorf("%s: expected an error at %s", tc.name, tc.attr)
+		}
+	}
+
+	data := minimalTFModel()
+	data.IPProtocolScope = tcpProtocolScope("IPV4")
+	data.Destination.TrafficFilter = &TrafficFilterModel{
+		Type: types.StringValue("PORT"),
+		PortFilter: &PortFilterModel{
+			Type:                  types.StringValue("TRAFFIC_MATCHING_LIST"),
+			TrafficMatchingListID: types.StringValue("list-ports"),
+		},
+	}
+	if diags := validatePolicy(data); diags.HasError() {
+		t.Errorf("expected a port list reference to be valid, got %v", diags)
+	}
+}
@@ -4,19 +4,32 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
 	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
 )
 
+var (
+	_ datasource.DataSource                     = &FirewallZoneDataSource{}
+	_ datasource.DataSourceWithConfigure        = &FirewallZoneDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &FirewallZoneDataSource{}
+)
+
 type FirewallZoneDataSource struct {
 	client *unifi.Client
 }
 
 type FirewallZoneDataSourceModel struct {
-	Name types.String `tfsdk:"name"`
-	ID   types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	ID           types.String `tfsdk:"id"`
+	NetworkIDs   types.Set    `tfsdk:"network_ids"`
+	Origin       types.String `tfsdk:"origin"`
+	Configurable types.Bool   `tfsdk:"configurable"`
+	Site         types.String `tfsdk:"site"`
 }
 
 func NewFirewallZoneDataSource() datasource.DataSource {
@@ -29,17 +42,42 @@ func (d *FirewallZoneDataSource) Metadata(ctx context.Context, req datasource.Me
 
 func (d *FirewallZoneDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a firewall zone by name or ID.",
 		Attributes: map[string]schema.Attribute{
+			"site": sitescope.DataSourceAttribute(),
 			"name": schema.StringAttribute{
-				Required: true,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The name of the zone. Exactly one of `name` or `id` must be set.",
 			},
 			"id": schema.StringAttribute{
-				Computed: true,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the zone. Exactly one of `name` or `id` must be set.",
+			},
+			"network_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "IDs of the networks in this zone.",
+			},
+			"origin": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "`SYSTEM_DEFINED` for the predefined zones, `USER_DEFINED` for custom zones.",
+			},
+			"configurable": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the zone can be managed with the `unifi_firewall_zone` resource.",
 			},
 		},
 	}
 }
 
+func (d *FirewallZoneDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("id")),
+	}
+}
+
 func (d *FirewallZoneDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -63,23 +101,57 @@ func (d *FirewallZoneDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	zones, err := d.client.ListFirewallZones()
+	ctx = sitescope.DataSourceContext(ctx, d.client, data.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zones, err := d.client.ListFirewallZones(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Error listing firewall zones", err.Error())
 		return
 	}
 
-	for _, zone := range zones {
-		if zone.Name == data.Name.ValueString() {
-			data.ID = types.StringValue(zone.ID)
-			break
-		}
+	zone, err := findZone(zones, data.ID.ValueString(), data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Zone not found", err.Error())
+		return
 	}
 
-	if data.ID.IsNull() {
-		resp.Diagnostics.AddError("Zone not found", fmt.Sprintf("Zone with name %s not found", data.Name.ValueString()))
+	networkIDs, diags := types.SetValueFrom(ctx, types.StringType, nonNilStrings(zone.NetworkIDs))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	data.ID = types.StringValue(zone.ID)
+	data.Name = types.StringValue(zone.Name)
+	data.NetworkIDs = networkIDs
+	data.Origin = types.StringValue(zone.Origin())
+	data.Configurable = types.BoolValue(!zone.Predefined())
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// findZone returns the zone with the given ID, or else the given name.
+func findZone(zones []unifi.FirewallZone, id, name string) (*unifi.FirewallZone, error) {
+	for i := range zones {
+		if id != "" && zones[i].ID == id {
+			return &zones[i], nil
+		}
+		if id == "" && zones[i].Name == name {
+			return &zones[i], nil
+		}
+	}
+	if id != "" {
+		return nil, fmt.Errorf("zone with ID %s not found", id)
+	}
+	return nil, fmt.Errorf("zone with name %s not found", name)
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,76 @@
+package firewall
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var testZones = []unifi.FirewallZone{
+	{ID: "zone-lan", Name: "Internal", NetworkIDs: []string{"net-1"}},
+	{ID: "zone-wan", Name: "External"},
+	{ID: "zone-iot", Name: "IoT", NetworkIDs: []string{"net-4"}, Metadata: &unifi.FirewallZoneMetadata{Origin: "USER_DEFINED"}},
+}
+
+func TestFindZone(t *testing.T) {
+	zone, err := findZone(testZones, "", "IoT")
+	if err != nil || zone.ID != "zone-iot" {
+		t.Errorf("by name: got %v, %v", zone, err)
+	}
+
+	zone, err = findZone(testZones, "zone-wan", "")
+	if err != nil || zone.Name != "External" {
+		t.Errorf("by id: got %v, %v", zone, err)
+	}
+
+	if _, err := findZone(testZones, "", "Missing"); err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Errorf("expected not found error naming the zone, got %v", err)
+	}
+	if _, err := findZone(testZones, "zone-x", ""); err == nil || !strings.Contains(err.Error(), "zone-x") {
+		t.Errorf("expected not found error naming the ID, got %v", err)
+	}
+}
+
+func TestZonesToMap(t *testing.T) {
+	m, diags := zonesToMap(context.Background(), testZones)
+	if diags.HasError() {
+		t.Fatalf("zonesToMap: %v", diags)
+	}
+
+	elems := m.Elements()
+	if len(elems) != 3 {
+		t.Fatalf("expected 3 zones, got %d", len(elems))
+	}
+
+	iot := elems["IoT"].(types.Object).Attributes()
+	if iot["id"].(types.String).ValueString() != "zone-iot" {
+		t.Errorf("expected IoT id zone-iot, got %v", iot["id"])
+	}
+	if !iot["configurable"].(types.Bool).ValueBool() {
+		t.Error("expected custom zone to be configurable")
+	}
+
+	wan := elems["External"].(types.Object).Attributes()
+	if wan["configurable"].(types.Bool).ValueBool() {
+		t.Error("expected predefined zone not to be configurable")
+	}
+	if wan["origin"].(types.String).ValueString() != "SYSTEM_DEFINED" {
+		t.Errorf("expected SYSTEM_DEFINED origin, got %v", wan["origin"])
+	}
+	if wan["network_ids"].(types.Set).IsNull() {
+		t.Error("expected empty, non-null network_ids")
+	}
+}
+
+func TestZonesToMap_DuplicateName(t *testing.T) {
+	_, diags := zonesToMap(context.Background(), []unifi.FirewallZone{
+		{ID: "a", Name: "IoT"},
+		{ID: "b", Name: "IoT"},
+	})
+	if !diags.HasError() {
+		t.Error("expected error for duplicate zone names")
+	}
+}
@@ -0,0 +1,139 @@
+package firewall
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+// zoneLister lists firewall zones at most once per call site, and only when
+// a zone actually has to be looked up.
+type zoneLister struct {
+	client *unifi.Client
+	zones  []unifi.FirewallZone
+	listed bool
+}
+
+func (l *zoneLister) list(ctx context.Context, diags *diag.Diagnostics) ([]unifi.FirewallZone, bool) {
+	if !l.listed {
+		zones, err := l.client.ListFirewallZones(ctx)
+		if err != nil {
+			diags.AddError("Error listing firewall zones", err.Error())
+			return nil, false
+		}
+		l.zones, l.listed = zones, true
+	}
+	return l.zones, true
+}
+
+// policySide is the source or destination block of a policy, with the name
+// of the block for diagnostics.
+type policySide struct {
+	name string
+	sd   *SourceDestModel
+}
+
+func policySides(data *FirewallPolicyResourceModel) []policySide {
+	return []policySide{{"source", data.Source}, {"destination", data.Destination}}
+}
+
+// forgetStaleZoneReferences undoes UseStateForUnknown on a side whose zone
+// moved: when one of zone_id and zone differs from state, the other one still
+// holds the prior zone and is set back to unknown to be resolved again. It
+// reports whether plan was changed.
+func forgetStaleZoneReferences(plan, state *FirewallPolicyResourceModel) bool {
+	changed := false
+	priorSides := policySides(state)
+	for i, side := range policySides(plan) {
+		sd, prior := side.sd, priorSides[i].sd
+		if sd == nil || prior == nil {
+			continue
+		}
+		idMoved := !sd.ZoneID.Equal(prior.ZoneID)
+		nameMoved := !sd.Zone.Equal(prior.Zone)
+		switch {
+		case idMoved && !nameMoved && !sd.Zone.IsNull():
+			sd.Zone = types.StringUnknown()
+			changed = true
+		case nameMoved && !idMoved && !sd.ZoneID.IsNull():
+			sd.ZoneID = types.StringUnknown()
+			changed = true
+		}
+	}
+	return changed
+}
+
+// resolveZoneReferences fills in whichever of zone_id and zone is missing on
+// each side of plan: the ID of a zone given by name, or the name of a zone
+// given by ID. Values computed by other resources, or a provider that is not
+// configured yet, stay unknown. It reports whether plan was changed.
+func (r *FirewallPolicyResource) resolveZoneReferences(ctx context.Context, plan *FirewallPolicyResourceModel, diags *diag.Diagnostics) bool {
+	if r.client == nil {
+		return false
+	}
+	lister := &zoneLister{client: r.client}
+	changed := false
+
+	for _, side := range policySides(plan) {
+		sd := side.sd
+		if sd == nil {
+			continue
+		}
+		byName := isKnown(sd.Zone) && !isKnown(sd.ZoneID)
+		byID := isKnown(sd.ZoneID) && sd.Zone.IsUnknown()
+		if !byName && !byID {
+			continue
+		}
+
+		zones, ok := lister.list(ctx, diags)
+		if !ok {
+			return false
+		}
+		if byName {
+			zone, err := findZone(zones, "", sd.Zone.ValueString())
+			if err != nil {
+				diags.AddAttributeError(path.Root(side.name).AtName("zone"), "Zone not found", err.Error())
+				continue
+			}
+			sd.ZoneID = types.StringValue(zone.ID)
+		} else {
+			sd.Zone = zoneName(zones, sd.ZoneID.ValueString())
+		}
+		changed = true
+	}
+	return changed
+}
+
+// refreshZoneNames sets zone on each side of data to the current name of its
+// zone_id, so that a zone renamed in the UniFi UI shows up as drift.
+func (r *FirewallPolicyResource) refreshZoneNames(ctx context.Context, data *FirewallPolicyResourceModel, diags *diag.Diagnostics) {
+	if r.client == nil {
+		return
+	}
+	lister := &zoneLister{client: r.client}
+
+	for _, side := range policySides(data) {
+		sd := side.sd
+		if sd == nil || !isKnown(sd.ZoneID) {
+			continue
+		}
+		zones, ok := lister.list(ctx, diags)
+		if !ok {
+			return
+		}
+		sd.Zone = zoneName(zones, sd.ZoneID.ValueString())
+	}
+}
+
+// zoneName returns the name of the zone with the given ID, or null when the
+// zone no longer exists.
+func zoneName(zones []unifi.FirewallZone, id string) types.String {
+	zone, err := findZone(zones, id, "")
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(zone.Name)
+}
@@ -0,0 +1,135 @@
+package firewall
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+func TestZoneName(t *testing.T) {
+	zones := []unifi.FirewallZone{
+		{ID: "zone-1", Name: "Internal"},
+		{ID: "zone-2", Name: "External"},
+	}
+	if got := zoneName(zones, "zone-2"); got.ValueString() != "External" {
+		t.Errorf("expected External, got %v", got)
+	}
+	if got := zoneName(zones, "zone-9"); !got.IsNull() {
+		t.Errorf("expected null for a deleted zone, got %v", got)
+	}
+}
+
+func TestValidatePolicy_ZoneReference(t *testing.T) {
+	data := minimalTFModel()
+	data.Source.ZoneID = types.StringNull()
+	data.Source.Zone = types.StringValue("Internal")
+	if diags := validatePolicy(data); diags.HasError() {
+		t.Errorf("expected a zone by name to be valid, got %v", diags)
+	}
+
+	data.Source.ZoneID = types.StringValue("zone-src")
+	if _, ok := errorAt(validatePolicy(data), "source.zone"); !ok {
+		t.Error("expected zone_id and zone together to be rejected")
+	}
+
+	data.Destination.ZoneID = types.StringNull()
+	data.Destination.Zone = types.StringNull()
+	if _, ok := errorAt(validatePolicy(data), "destination.zone_id"); !ok {
+		t.Error("expected a destination without a zone to be rejected")
+	}
+}
+
+func TestResolveZoneReferences_WithoutClient(t *testing.T) {
+	data := minimalTFModel()
+	data.Source.ZoneID = types.StringUnknown()
+	data.Source.Zone = types.StringValue("Internal")
+
+	var diags diag.Diagnostics
+	if newTestResource().resolveZoneReferences(context.Background(), &data, &diags) {
+		t.Error("expected the plan to be left alone before the provider is configured")
+	}
+	if !data.Source.ZoneID.IsUnknown() || diags.HasError() {
+		t.Errorf("expected zone_id to stay unknown, got %v (%v)", data.Source.ZoneID, diags)
+	}
+}
+
+func TestForgetStaleZoneReferences(t *testing.T) {
+	state := minimalTFModel()
+	state.Source.Zone = types.StringValue("Source")
+	state.Destination.Zone = types.StringValue("Destination")
+
+	// Unchanged zones keep both values from state.
+	plan := minimalTFModel()
+	plan.Source.Zone = types.StringValue("Source")
+	plan.Destination.Zone = types.StringValue("Destination")
+	if forgetStaleZoneReferences(&plan, &state) {
+		t.Errorf("expected unchanged zones to be kept, got %+v and %+v", plan.Source, plan.Destination)
+	}
+
+	// A new zone_id leaves the prior zone name behind, and a new zone name
+	// the prior zone_id.
+	plan.Source.ZoneID = types.StringValue("zone-other")
+	plan.Destination.Zone = types.StringValue("Other")
+	if !forgetStaleZoneReferences(&plan, &state) {
+		t.Fatal("expected the plan to change")
+	}
+	if !plan.Source.Zone.IsUnknown() || plan.Source.ZoneID.ValueString() != "zone-other" {
+		t.Errorf("source: expected zone to be unknown, got %+v", plan.Source)
+	}
+	if !plan.Destination.ZoneID.IsUnknown() || plan.Destination.Zone.ValueString() != "Other" {
+		t.Errorf("destination: expected zone_id to be unknown, got %+v", plan.Destination)
+	}
+}
+
+func TestModifyPlan_ResolvesZonesOnPolicySite(t *testing.T) {
+	ctx := context.Background()
+	r := &FirewallPolicyResource{client: newMockClient(t, nil, map[string][]unifi.FirewallZone{
+		"site-1": {{ID: "zone-1", Name: "IoT"}},
+		"site-2": {{ID: "zone-2", Name: "IoT"}},
+	}, nil)}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	cases := map[string]struct {
+		site       types.String
+		wantZoneID types.String
+	}{
+		"provider site": {types.StringValue("site-1"), types.StringValue("zone-1")},
+		"other site":    {types.StringValue("site-2"), types.StringValue("zone-2")},
+		// The site may come from another resource; nothing can be looked up
+		// until it is known.
+		"unknown site": {types.StringUnknown(), types.StringUnknown()},
+	}
+	for name, tc := range cases {
+		data := minimalTFModel()
+		data.Site = tc.site
+		data.Source.ZoneID = types.StringUnknown()
+		data.Source.Zone = types.StringValue("IoT")
+		data.Destination.Zone = types.StringNull()
+
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		if diags := plan.Set(ctx, &data); diags.HasError() {
+			t.Fatalf("%s: %v", name, diags)
+		}
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected errors: %v", name, resp.Diagnostics)
+		}
+
+		var got FirewallPolicyResourceModel
+		resp.Plan.Get(ctx, &got)
+		if !got.Site.Equal(tc.site) {
+			t.Errorf("%s: expected site %v, got %v", name, tc.site, got.Site)
+		}
+		if !got.Source.ZoneID.Equal(tc.wantZoneID) {
+			t.Errorf("%s: expected zone_id %v, got %v", name, tc.wantZoneID, got.Source.ZoneID)
+		}
+	}
+}
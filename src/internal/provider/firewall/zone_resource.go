@@ -0,0 +1,237 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var (
+	_ resource.Resource                = &FirewallZoneResource{}
+	_ resource.ResourceWithConfigure   = &FirewallZoneResource{}
+	_ resource.ResourceWithImportState = &FirewallZoneResource{}
+)
+
+func NewFirewallZoneResource() resource.Resource {
+	return &FirewallZoneResource{}
+}
+
+// FirewallZoneResource manages a custom (user-defined) firewall zone. The
+// built-in zones are read-only and only available through the data source.
+type FirewallZoneResource struct {
+	client *unifi.Client
+}
+
+type FirewallZoneResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	NetworkIDs types.Set    `tfsdk:"network_ids"`
+	Site       types.String `tfsdk:"site"`
+}
+
+func (r *FirewallZoneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_zone"
+}
+
+func (r *FirewallZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A custom firewall zone and the networks assigned to it. " +
+			"Predefined zones (Internal, External, Gateway, VPN, Hotspot, DMZ) cannot be managed; use the `unifi_firewall_zone` data source to reference them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the zone.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"site": sitescope.ResourceAttribute(func() *unifi.Client { return r.client }),
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the zone. Must not be the name of a predefined zone.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.NoneOfCaseInsensitive(unifi.PredefinedZoneNames...),
+				},
+			},
+			"network_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, nil)),
+				MarkdownDescription: "IDs of the networks in this zone. A network can only belong to one zone, so adding it here moves it out of its current zone.",
+			},
+		},
+	}
+}
+
+func (r *FirewallZoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*unifi.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", "Expected *unifi.Client")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FirewallZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan FirewallZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &plan.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, diags := zoneFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateFirewallZone(ctx, zone)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating firewall zone", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(created.ID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FirewallZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state FirewallZoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &state.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.GetFirewallZone(ctx, state.ID.ValueString())
+	if err != nil {
+		if unifi.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading firewall zone", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(zoneToModel(ctx, zone, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *FirewallZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan FirewallZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &plan.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, diags := zoneFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.UpdateFirewallZone(ctx, plan.ID.ValueString(), zone); err != nil {
+		resp.Diagnostics.AddError("Error updating firewall zone", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FirewallZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state FirewallZoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &state.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteFirewallZone(ctx, state.ID.ValueString())
+	if err != nil && !unifi.IsNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting firewall zone", err.Error())
+		return
+	}
+}
+
+// ImportState accepts a zone ID, or <site>/<id> for a zone of another site,
+// and refuses predefined zones, which would otherwise be deleted on destroy.
+func (r *FirewallZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id := sitescope.ImportContext(ctx, r.client, req.ID, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.GetFirewallZone(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing firewall zone", err.Error())
+		return
+	}
+	if zone.Predefined() {
+		resp.Diagnostics.AddError(
+			"Cannot import predefined firewall zone",
+			fmt.Sprintf("Zone %q is built in and read-only. Reference it with the unifi_firewall_zone data source instead.", zone.Name),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func zoneFromModel(ctx context.Context, m FirewallZoneResourceModel) (unifi.FirewallZone, diag.Diagnostics) {
+	zone := unifi.FirewallZone{
+		Name:       m.Name.ValueString(),
+		NetworkIDs: []string{},
+	}
+	diags := m.NetworkIDs.ElementsAs(ctx, &zone.NetworkIDs, false)
+	return zone, diags
+}
+
+func zoneToModel(ctx context.Context, zone *unifi.FirewallZone, m *FirewallZoneResourceModel) diag.Diagnostics {
+	m.ID = types.StringValue(zone.ID)
+	m.Name = types.StringValue(zone.Name)
+
+	networkIDs, diags := types.SetValueFrom(ctx, types.StringType, nonNilStrings(zone.NetworkIDs))
+	m.NetworkIDs = networkIDs
+	return diags
+}
@@ -0,0 +1,100 @@
+package firewall
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+func TestZoneModelRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	zone := &unifi.FirewallZone{ID: "zone-1", Name: "IoT", NetworkIDs: []string{"net-1", "net-2"}}
+
+	var m FirewallZoneResourceModel
+	if diags := zoneToModel(ctx, zone, &m); diags.HasError() {
+		t.Fatalf("zoneToModel: %v", diags)
+	}
+	if m.ID.ValueString() != "zone-1" || m.Name.ValueString() != "IoT" {
+		t.Errorf("unexpected model: %+v", m)
+	}
+	if len(m.NetworkIDs.Elements()) != 2 {
+		t.Errorf("expected 2 network IDs, got %v", m.NetworkIDs)
+	}
+
+	back, diags := zoneFromModel(ctx, m)
+	if diags.HasError() {
+		t.Fatalf("zoneFromModel: %v", diags)
+	}
+	if back.Name != "IoT" || len(back.NetworkIDs) != 2 {
+		t.Errorf("unexpected zone: %+v", back)
+	}
+	if back.ID != "" {
+		t.Errorf("expected ID to be left out of the request body, got %q", back.ID)
+	}
+}
+
+func TestZoneToModel_NoNetworks(t *testing.T) {
+	var m FirewallZoneResourceModel
+	if diags := zoneToModel(context.Background(), &unifi.FirewallZone{ID: "zone-1", Name: "Empty"}, &m); diags.HasError() {
+		t.Fatalf("zoneToModel: %v", diags)
+	}
+	if m.NetworkIDs.IsNull() || len(m.NetworkIDs.Elements()) != 0 {
+		t.Errorf("expected an empty, non-null set to match the schema default, got %v", m.NetworkIDs)
+	}
+}
+
+func TestZoneNameRejectsPredefined(t *testing.T) {
+	v := stringvalidator.NoneOfCaseInsensitive(unifi.PredefinedZoneNames...)
+	for name, wantErr := range map[string]bool{"External": true, "vpn": true, "dmz": true, "IoT": false} {
+		resp := &validator.StringResponse{}
+		v.ValidateString(context.Background(), validator.StringRequest{ConfigValue: types.StringValue(name)}, resp)
+		if resp.Diagnostics.HasError() != wantErr {
+			t.Errorf("name %q: got error=%v, want %v", name, resp.Diagnostics.HasError(), wantErr)
+		}
+	}
+}
+
+func TestZoneImportState_OtherSite(t *testing.T) {
+	ctx := context.Background()
+	r := &FirewallZoneResource{client: newMockClient(t, nil, map[string][]unifi.FirewallZone{
+		"site-1": {{ID: "zone-lan", Name: "LAN"}},
+		"site-2": {{ID: "zone-iot", Name: "IoT"}, {ID: "zone-lan", Name: "LAN", Metadata: &unifi.FirewallZoneMetadata{Origin: "SYSTEM_DEFINED"}}},
+	}, nil)}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	importZone := func(importID string) resource.ImportStateResponse {
+		resp := resource.ImportStateResponse{State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: importID}, &resp)
+		return resp
+	}
+
+	resp := importZone("site-2/zone-iot")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	var id, site types.String
+	resp.State.GetAttribute(ctx, path.Root("id"), &id)
+	resp.State.GetAttribute(ctx, path.Root("site"), &site)
+	if id.ValueString() != "zone-iot" || site.ValueString() != "site-2" {
+		t.Errorf("expected zone-iot on site-2, got %s on %s", id, site)
+	}
+
+	// zone-lan is user defined on the provider's site but built in on site-2.
+	if resp := importZone("zone-lan"); resp.Diagnostics.HasError() {
+		t.Errorf("zone-lan: unexpected errors: %v", resp.Diagnostics)
+	}
+	if resp := importZone("site-2/zone-lan"); !resp.Diagnostics.HasError() {
+		t.Error("site-2/zone-lan: expected the predefined zone to be refused")
+	}
+}
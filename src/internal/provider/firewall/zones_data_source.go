@@ -0,0 +1,162 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var (
+	_ datasource.DataSource              = &FirewallZonesDataSource{}
+	_ datasource.DataSourceWithConfigure = &FirewallZonesDataSource{}
+)
+
+// zoneAttrTypes is the object type of each entry in the zones map.
+var zoneAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"name":         types.StringType,
+	"network_ids":  types.SetType{ElemType: types.StringType},
+	"origin":       types.StringType,
+	"configurable": types.BoolType,
+}
+
+// FirewallZonesDataSource returns every firewall zone of the site in one call.
+type FirewallZonesDataSource struct {
+	client *unifi.Client
+}
+
+type FirewallZonesDataSourceModel struct {
+	Zones types.Map    `tfsdk:"zones"`
+	Site  types.String `tfsdk:"site"`
+}
+
+func NewFirewallZonesDataSource() datasource.DataSource {
+	return &FirewallZonesDataSource{}
+}
+
+func (d *FirewallZonesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_zones"
+}
+
+func (d *FirewallZonesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all firewall zones of the site.",
+		Attributes: map[string]schema.Attribute{
+			"site": sitescope.DataSourceAttribute(),
+			"zones": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "All zones, keyed by zone name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the zone.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the zone.",
+						},
+						"network_ids": schema.SetAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "IDs of the networks in this zone.",
+						},
+						"origin": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "`SYSTEM_DEFINED` for the predefined zones, `USER_DEFINED` for custom zones.",
+						},
+						"configurable": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the zone can be managed with the `unifi_firewall_zone` resource.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *FirewallZonesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*unifi.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *unifi.Client, got %T", req.ProviderData))
+		return
+	}
+
+	d.client = client
+}
+
+func (d *FirewallZonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FirewallZonesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.DataSourceContext(ctx, d.client, data.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zones, err := d.client.ListFirewallZones(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing firewall zones", err.Error())
+		return
+	}
+
+	zoneMap, diags := zonesToMap(ctx, zones)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Zones = zoneMap
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// zonesToMap keys zones by name. Zone names are unique per site, so a
+// duplicate means the controller returned inconsistent data.
+func zonesToMap(ctx context.Context, zones []unifi.FirewallZone) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	elems := make(map[string]attr.Value, len(zones))
+
+	for _, zone := range zones {
+		if _, dup := elems[zone.Name]; dup {
+			diags.AddError("Duplicate zone name", fmt.Sprintf("More than one firewall zone is named %q.", zone.Name))
+			return types.MapNull(types.ObjectType{AttrTypes: zoneAttrTypes}), diags
+		}
+
+		networkIDs, d := types.SetValueFrom(ctx, types.StringType, nonNilStrings(zone.NetworkIDs))
+		diags.Append(d...)
+
+		obj, d := types.ObjectValue(zoneAttrTypes, map[string]attr.Value{
+			"id":           types.StringValue(zone.ID),
+			"name":         types.StringValue(zone.Name),
+			"network_ids":  networkIDs,
+			"origin":       types.StringValue(zone.Origin()),
+			"configurable": types.BoolValue(!zone.Predefined()),
+		})
+		diags.Append(d...)
+		if diags.HasError() {
+			return types.MapNull(types.ObjectType{AttrTypes: zoneAttrTypes}), diags
+		}
+		elems[zone.Name] = obj
+	}
+
+	m, d := types.MapValue(types.ObjectType{AttrTypes: zoneAttrTypes}, elems)
+	diags.Append(d...)
+	return m, diags
+}
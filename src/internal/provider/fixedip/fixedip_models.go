@@ -10,4 +10,5 @@ type FixedIPResourceModel struct {
 	NetworkID types.String `tfsdk:"network_id"`
 	FixedIP   types.String `tfsdk:"fixed_ip"`
 	Name      types.String `tfsdk:"name"`
+	Site      types.String `tfsdk:"site"`
 }
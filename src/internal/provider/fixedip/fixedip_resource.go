@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
 	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
 )
 
@@ -66,6 +67,7 @@ func (r *FixedIPResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"site": sitescope.ResourceAttribute(func() *unifi.Client { return r.client }),
 		},
 	}
 }
@@ -94,11 +96,15 @@ func (r *FixedIPResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	siteID := r.client.SiteID
+	ctx = sitescope.Context(ctx, r.client, &plan.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	siteID := r.client.ContextSiteID(ctx)
 	mac := strings.ToLower(plan.MAC.ValueString())
 
 	// Look up client by MAC address
-	clients, err := r.client.ListClients(siteID)
+	clients, err := r.client.ListClients(ctx, siteID)
 	if err != nil {
 		resp.Diagnostics.AddError("Error listing clients", err.Error())
 		return
@@ -127,7 +133,7 @@ func (r *FixedIPResource) Create(ctx context.Context, req resource.CreateRequest
 		name = clientName
 	}
 
-	dev, err := r.client.SetClientFixedIP(siteID, clientID, plan.NetworkID.ValueString(), plan.FixedIP.ValueString(), name)
+	dev, err := r.client.SetClientFixedIP(ctx, siteID, clientID, plan.NetworkID.ValueString(), plan.FixedIP.ValueString(), name)
 	if err != nil {
 		resp.Diagnostics.AddError("Error setting fixed IP", err.Error())
 		return
@@ -146,10 +152,19 @@ func (r *FixedIPResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	siteID := r.client.SiteID
+	ctx = sitescope.Context(ctx, r.client, &state.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	siteID := r.client.ContextSiteID(ctx)
 
-	dev, err := r.client.GetClient(siteID, state.ID.ValueString())
+	dev, err := r.client.GetClient(ctx, siteID, state.ID.ValueString())
 	if err != nil {
+		if unifi.IsNotFound(err) {
+			// Client was forgotten by the controller
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Error reading client", err.Error())
 		return
 	}
@@ -175,11 +190,15 @@ func (r *FixedIPResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	siteID := r.client.SiteID
+	ctx = sitescope.Context(ctx, r.client, &plan.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	siteID := r.client.ContextSiteID(ctx)
 
 	name := plan.Name.ValueString()
 
-	dev, err := r.client.SetClientFixedIP(siteID, plan.ID.ValueString(), plan.NetworkID.ValueString(), plan.FixedIP.ValueString(), name)
+	dev, err := r.client.SetClientFixedIP(ctx, siteID, plan.ID.ValueString(), plan.NetworkID.ValueString(), plan.FixedIP.ValueString(), name)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating fixed IP", err.Error())
 		return
@@ -197,15 +216,25 @@ func (r *FixedIPResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	siteID := r.client.SiteID
+	ctx = sitescope.Context(ctx, r.client, &state.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	siteID := r.client.ContextSiteID(ctx)
 
-	err := r.client.UnsetClientFixedIP(siteID, state.ID.ValueString())
+	err := r.client.UnsetClientFixedIP(ctx, siteID, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error removing fixed IP", err.Error())
 		return
 	}
 }
 
+// ImportState accepts <id>, or <site>/<id> for an object of another site.
 func (r *FixedIPResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx, id := sitescope.ImportContext(ctx, r.client, req.ID, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -3,22 +3,30 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/netip"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
 	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
 )
 
+var (
+	_ datasource.DataSource                     = &NetworkDataSource{}
+	_ datasource.DataSourceWithConfigure        = &NetworkDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &NetworkDataSource{}
+)
+
 type NetworkDataSource struct {
 	client *unifi.Client
 }
 
-type NetworkDataSourceModel struct {
-	Name   types.String `tfsdk:"name"`
-	ID     types.String `tfsdk:"id"`
-	VlanID types.Int64  `tfsdk:"vlan_id"`
-}
+// NetworkDataSourceModel exposes the same attributes as the unifi_network
+// resource, so lookups and managed networks can be used interchangeably.
+type NetworkDataSourceModel = NetworkResourceModel
 
 func NewNetworkDataSource() datasource.DataSource {
 	return &NetworkDataSource{}
@@ -29,18 +37,45 @@ func (d *NetworkDataSource) Metadata(ctx context.Context, req datasource.Metadat
 }
 
 func (d *NetworkDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := networkDataSourceAttributes()
+
+	attributes["site"] = sitescope.DataSourceAttribute()
+	lookup := "Exactly one of `id`, `name`, `vlan_id` or `subnet` must be set."
+	attributes["id"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "The ID of the network. " + lookup,
+	}
+	attributes["name"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "The name of the network. " + lookup,
+	}
+	attributes["vlan_id"] = schema.Int64Attribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "The VLAN ID of the network. " + lookup,
+	}
+	attributes["subnet"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "The IPv4 subnet in CIDR notation. " + lookup,
+	}
+
 	resp.Schema = schema.Schema{
-		Attributes: map[string]schema.Attribute{
-			"name": schema.StringAttribute{
-				Required: true,
-			},
-			"id": schema.StringAttribute{
-				Computed: true,
-			},
-			"vlan_id": schema.Int64Attribute{
-				Computed: true,
-			},
-		},
+		MarkdownDescription: "Looks up a network by ID, name, VLAN ID or subnet.",
+		Attributes:          attributes,
+	}
+}
+
+func (d *NetworkDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+			path.MatchRoot("vlan_id"),
+			path.MatchRoot("subnet"),
+		),
 	}
 }
 
@@ -67,24 +102,183 @@ func (d *NetworkDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	networks, err := d.client.ListNetworks()
+	ctx = sitescope.DataSourceContext(ctx, d.client, data.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	networks, err := d.client.ListNetworks(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Error listing networks", err.Error())
 		return
 	}
 
-	for _, network := range networks {
-		if network.Name == data.Name.ValueString() {
-			data.ID = types.StringValue(network.ID)
-			data.VlanID = types.Int64Value(int64(network.VlanID))
-			break
+	var lookup networkLookup
+	switch {
+	case !data.ID.IsNull():
+		lookup.id = data.ID.ValueString()
+	case !data.Name.IsNull():
+		lookup.name = data.Name.ValueString()
+	case !data.VlanID.IsNull():
+		vlan := int(data.VlanID.ValueInt64())
+		lookup.vlanID = &vlan
+	case !data.Subnet.IsNull():
+		subnet, err := netip.ParsePrefix(data.Subnet.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("subnet"), "Invalid subnet", err.Error())
+			return
 		}
+		lookup.subnet = subnet.Masked()
+	}
+
+	network, err := findNetwork(networks, lookup)
+	if err != nil {
+		resp.Diagnostics.AddError("Network not found", err.Error())
+		return
 	}
 
-	if data.ID.IsNull() {
-		resp.Diagnostics.AddError("Network not found", fmt.Sprintf("Network with name %s not found", data.Name.ValueString()))
+	resp.Diagnostics.Append(networkToModel(ctx, network, &data)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// networkLookup holds the single criterion a network is looked up by.
+type networkLookup struct {
+	id     string
+	name   string
+	vlanID *int
+	subnet netip.Prefix
+}
+
+func (l networkLookup) String() string {
+	switch {
+	case l.id != "":
+		return "ID " + l.id
+	case l.vlanID != nil:
+		return fmt.Sprintf("VLAN ID %d", *l.vlanID)
+	case l.subnet.IsValid():
+		return "subnet " + l.subnet.String()
+	default:
+		return "name " + l.name
+	}
+}
+
+func (l networkLookup) matches(n unifi.Network) bool {
+	switch {
+	case l.id != "":
+		return n.ID == l.id
+	case l.vlanID != nil:
+		return n.VlanID == *l.vlanID
+	case l.subnet.IsValid():
+		subnet, ok := networkSubnet(n)
+		return ok && subnet == l.subnet
+	default:
+		return n.Name == l.name
+	}
+}
+
+// findNetwork returns the single network matching lookup. Names and subnets
+// are not guaranteed to be unique, so several matches are an error.
+func findNetwork(networks []unifi.Network, lookup networkLookup) (*unifi.Network, error) {
+	var found *unifi.Network
+	for i := range networks {
+		if !lookup.matches(networks[i]) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("more than one network with %s (IDs %s and %s)", lookup, found.ID, networks[i].ID)
+		}
+		found = &networks[i]
+	}
+	if found == nil {
+		return nil, fmt.Errorf("network with %s not found", lookup)
+	}
+	return found, nil
+}
+
+// networkSubnet returns the IPv4 subnet of n, if it has one.
+func networkSubnet(n unifi.Network) (netip.Prefix, bool) {
+	if n.IPv4Configuration == nil || n.IPv4Configuration.HostIPAddress == "" {
+		return netip.Prefix{}, false
+	}
+	addr, err := netip.ParseAddr(n.IPv4Configuration.HostIPAddress)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+	return netip.PrefixFrom(addr, n.IPv4Configuration.PrefixLength).Masked(), true
+}
+
+// networkDataSourceAttributes returns the read-only network attributes shared
+// by unifi_network and the entries of unifi_networks.
+func networkDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The ID of the network.",
+		},
+		"site": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The site the network was read from, as configured on the data source.",
+		},
+		"name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The name of the network.",
+		},
+		"vlan_id": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "The VLAN ID of the network.",
+		},
+		"management": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Who routes the network: `GATEWAY`, `SWITCH` or `UNMANAGED`.",
+		},
+		"enabled": schema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "Whether the network is enabled.",
+		},
+		"subnet": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The IPv4 subnet in CIDR notation.",
+		},
+		"gateway": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The gateway IP address.",
+		},
+		"dhcp_start": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "First address of the DHCP range. Null when the DHCP server is off.",
+		},
+		"dhcp_stop": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Last address of the DHCP range.",
+		},
+		"dhcp_lease_time": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "DHCP lease time in seconds.",
+		},
+		"dhcp_dns_servers": schema.ListAttribute{
+			ElementType:         types.StringType,
+			Computed:            true,
+			MarkdownDescription: "DNS servers handed out by DHCP, if overridden.",
+		},
+		"domain_name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Domain name handed out by DHCP.",
+		},
+		"ipv6_mode": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "IPv6 interface type, or null when IPv6 is off.",
+		},
+		"isolation_enabled": schema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "Whether clients on this network are isolated from other networks.",
+		},
+		"zone_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The ID of the firewall zone the network belongs to.",
+		},
+	}
+}
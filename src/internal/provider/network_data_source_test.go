@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"net/netip"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var testNetworks = []unifi.Network{
+	{ID: "net-1", Name: "Default", VlanID: 1, Management: "GATEWAY",
+		IPv4Configuration: &unifi.NetworkIPv4Configuration{HostIPAddress: "192.168.1.1", PrefixLength: 24}},
+	{ID: "net-2", Name: "Guest", VlanID: 100, Management: "GATEWAY",
+		IPv4Configuration: &unifi.NetworkIPv4Configuration{HostIPAddress: "10.0.100.1", PrefixLength: 24}},
+	{ID: "net-3", Name: "IoT", VlanID: 200, Management: "SWITCH"},
+	{ID: "net-4", Name: "IoT", VlanID: 201, Management: "GATEWAY"},
+}
+
+func TestFindNetwork(t *testing.T) {
+	vlan := 100
+	tests := []struct {
+		name   string
+		lookup networkLookup
+		wantID string
+	}{
+		{"by id", networkLookup{id: "net-3"}, "net-3"},
+		{"by name", networkLookup{name: "Guest"}, "net-2"},
+		{"by vlan", networkLookup{vlanID: &vlan}, "net-2"},
+		{"by subnet", networkLookup{subnet: netip.MustParsePrefix("192.168.1.0/24")}, "net-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findNetwork(testNetworks, tt.lookup)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ID != tt.wantID {
+				t.Errorf("expected %s, got %s", tt.wantID, got.ID)
+			}
+		})
+	}
+}
+
+func TestFindNetwork_Errors(t *testing.T) {
+	_, err := findNetwork(testNetworks, networkLookup{name: "IoT"})
+	if err == nil || !strings.Contains(err.Error(), "net-3") || !strings.Contains(err.Error(), "net-4") {
+		t.Errorf("expected ambiguity error listing both IDs, got %v", err)
+	}
+
+	vlan := 4000
+	_, err = findNetwork(testNetworks, networkLookup{vlanID: &vlan})
+	if err == nil || !strings.Contains(err.Error(), "VLAN ID 4000") {
+		t.Errorf("expected not found error naming the VLAN, got %v", err)
+	}
+}
+
+func TestNetworkFilter(t *testing.T) {
+	lo, hi := 100, 200
+	tests := []struct {
+		name   string
+		filter networkFilter
+		want   []string
+	}{
+		{"no filter", networkFilter{}, []string{"net-1", "net-2", "net-3", "net-4"}},
+		{"name regex", networkFilter{nameRegex: regexp.MustCompile("^(Guest|IoT)$")}, []string{"net-2", "net-3", "net-4"}},
+		{"vlan range", networkFilter{vlanMin: &lo, vlanMax: &hi}, []string{"net-2", "net-3"}},
+		{"management", networkFilter{management: "SWITCH"}, []string{"net-3"}},
+		{"combined", networkFilter{nameRegex: regexp.MustCompile("IoT"), management: "GATEWAY"}, []string{"net-4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, n := range testNetworks {
+				if tt.filter.matches(n) {
+					got = append(got, n.ID)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,522 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var (
+	_ resource.Resource                   = &NetworkResource{}
+	_ resource.ResourceWithConfigure      = &NetworkResource{}
+	_ resource.ResourceWithImportState    = &NetworkResource{}
+	_ resource.ResourceWithValidateConfig = &NetworkResource{}
+)
+
+func NewNetworkResource() resource.Resource {
+	return &NetworkResource{}
+}
+
+// NetworkResource manages a network (VLAN) and its IPv4/DHCP settings.
+type NetworkResource struct {
+	client *unifi.Client
+}
+
+type NetworkResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	VlanID           types.Int64  `tfsdk:"vlan_id"`
+	Management       types.String `tfsdk:"management"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	Subnet           types.String `tfsdk:"subnet"`
+	Gateway          types.String `tfsdk:"gateway"`
+	DHCPStart        types.String `tfsdk:"dhcp_start"`
+	DHCPStop         types.String `tfsdk:"dhcp_stop"`
+	DHCPLeaseTime    types.Int64  `tfsdk:"dhcp_lease_time"`
+	DHCPDNSServers   types.List   `tfsdk:"dhcp_dns_servers"`
+	DomainName       types.String `tfsdk:"domain_name"`
+	IPv6Mode         types.String `tfsdk:"ipv6_mode"`
+	IsolationEnabled types.Bool   `tfsdk:"isolation_enabled"`
+	ZoneID           types.String `tfsdk:"zone_id"`
+	Site             types.String `tfsdk:"site"`
+}
+
+func (r *NetworkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network"
+}
+
+func (r *NetworkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A network (VLAN) with its IPv4 subnet and DHCP server settings.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the network.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"site": sitescope.ResourceAttribute(func() *unifi.Client { return r.client }),
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the network.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vlan_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The VLAN ID (2-4009). VLAN 1 is reserved for the default network.",
+				Validators: []validator.Int64{
+					int64validator.Between(2, 4009),
+				},
+			},
+			"management": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("GATEWAY"),
+				MarkdownDescription: "Who routes the network: `GATEWAY` (default), `SWITCH` or `UNMANAGED`. Changing it recreates the network.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("GATEWAY", "SWITCH", "UNMANAGED"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the network is enabled.",
+			},
+			"subnet": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The IPv4 subnet in CIDR notation, e.g. `10.0.30.0/24`.",
+				Validators: []validator.String{
+					ipv4PrefixValidator{},
+				},
+			},
+			"gateway": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The gateway IP address within `subnet`. Defaults to the first host address.",
+				Validators: []validator.String{
+					ipv4AddressValidator{},
+					stringvalidator.AlsoRequires(path.MatchRoot("subnet")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					defaultGatewayFollowsSubnet{},
+				},
+			},
+			"dhcp_start": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "First address of the DHCP range. Setting a range enables the DHCP server.",
+				Validators: []validator.String{
+					ipv4AddressValidator{},
+					stringvalidator.AlsoRequires(path.MatchRoot("dhcp_stop"), path.MatchRoot("subnet")),
+				},
+			},
+			"dhcp_stop": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Last address of the DHCP range.",
+				Validators: []validator.String{
+					ipv4AddressValidator{},
+					stringvalidator.AlsoRequires(path.MatchRoot("dhcp_start")),
+				},
+			},
+			"dhcp_lease_time": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "DHCP lease time in seconds. Defaults to the controller's default (usually 86400).",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(60),
+					int64validator.AlsoRequires(path.MatchRoot("dhcp_start")),
+				},
+			},
+			"dhcp_dns_servers": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "DNS servers handed out by DHCP instead of the gateway. At most 4.",
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 4),
+					listvalidator.ValueStringsAre(ipv4AddressValidator{}),
+					listvalidator.AlsoRequires(path.MatchRoot("dhcp_start")),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Domain name handed out by DHCP.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("dhcp_start")),
+				},
+			},
+			"ipv6_mode": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "IPv6 interface type: `PREFIX_DELEGATION` or `STATIC`. Leave unset to disable IPv6.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("PREFIX_DELEGATION", "STATIC"),
+				},
+			},
+			"isolation_enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether clients on this network are isolated from other networks.",
+			},
+			"zone_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the firewall zone the network belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// defaultGatewayFollowsSubnet undoes UseStateForUnknown on a gateway that is
+// not configured when the subnet changes, since the default gateway moves
+// with it.
+type defaultGatewayFollowsSubnet struct{}
+
+func (m defaultGatewayFollowsSubnet) Description(ctx context.Context) string {
+	return "Recomputes the default gateway when the subnet changes."
+}
+
+func (m defaultGatewayFollowsSubnet) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m defaultGatewayFollowsSubnet) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || !req.ConfigValue.IsNull() {
+		return
+	}
+	var planned, prior types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("subnet"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("subnet"), &prior)...)
+	if !planned.Equal(prior) {
+		resp.PlanValue = types.StringUnknown()
+	}
+}
+
+func (r *NetworkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*unifi.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *unifi.Client, got %T", req.ProviderData))
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks that the gateway and DHCP range lie inside the subnet.
+func (r *NetworkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NetworkResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateNetworkAddresses(data)...)
+}
+
+func (r *NetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan NetworkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &plan.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	network, diags := networkFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateNetwork(ctx, network)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating network", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(networkToModel(ctx, created, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NetworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state NetworkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &state.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	network, err := r.client.GetNetwork(ctx, state.ID.ValueString())
+	if err != nil {
+		if unifi.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading network", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(networkToModel(ctx, network, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *NetworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan NetworkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &plan.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	network, diags := networkFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.client.UpdateNetwork(ctx, plan.ID.ValueString(), network)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating network", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(networkToModel(ctx, updated, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NetworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state NetworkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.Context(ctx, r.client, &state.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteNetwork(ctx, state.ID.ValueString())
+	if err != nil && !unifi.IsNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting network", err.Error())
+		return
+	}
+}
+
+// ImportState accepts <id>, or <site>/<id> for an object of another site.
+func (r *NetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, id := sitescope.ImportContext(ctx, r.client, req.ID, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// validateNetworkAddresses checks the gateway and DHCP range against the
+// subnet. Unknown or invalid values are skipped; the attribute validators
+// report malformed addresses.
+func validateNetworkAddresses(data NetworkResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !isKnown(data.Subnet) {
+		return diags
+	}
+	subnet, err := netip.ParsePrefix(data.Subnet.ValueString())
+	if err != nil {
+		return diags
+	}
+
+	inSubnet := func(attr string, v types.String) (netip.Addr, bool) {
+		if !isKnown(v) {
+			return netip.Addr{}, false
+		}
+		addr, err := netip.ParseAddr(v.ValueString())
+		if err != nil {
+			return netip.Addr{}, false
+		}
+		if !subnet.Contains(addr) {
+			diags.AddAttributeError(path.Root(attr), "Address outside subnet",
+				fmt.Sprintf("%s is not in subnet %s.", addr, subnet))
+			return netip.Addr{}, false
+		}
+		return addr, true
+	}
+
+	inSubnet("gateway", data.Gateway)
+	start, okStart := inSubnet("dhcp_start", data.DHCPStart)
+	stop, okStop := inSubnet("dhcp_stop", data.DHCPStop)
+	if okStart && okStop && stop.Less(start) {
+		diags.AddAttributeError(path.Root("dhcp_stop"), "Invalid DHCP range",
+			fmt.Sprintf("dhcp_stop (%s) must not be lower than dhcp_start (%s).", stop, start))
+	}
+
+	return diags
+}
+
+func isKnown(v types.String) bool {
+	return !v.IsNull() && !v.IsUnknown() && v.ValueString() != ""
+}
+
+func networkFromModel(ctx context.Context, m NetworkResourceModel) (unifi.Network, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	network := unifi.Network{
+		Name:             m.Name.ValueString(),
+		VlanID:           int(m.VlanID.ValueInt64()),
+		Management:       m.Management.ValueString(),
+		Enabled:          m.Enabled.ValueBool(),
+		IsolationEnabled: m.IsolationEnabled.ValueBool(),
+	}
+
+	if isKnown(m.Subnet) {
+		subnet, err := netip.ParsePrefix(m.Subnet.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("subnet"), "Invalid subnet", err.Error())
+			return network, diags
+		}
+
+		gateway := subnet.Masked().Addr().Next().String()
+		if isKnown(m.Gateway) {
+			gateway = m.Gateway.ValueString()
+		}
+
+		network.IPv4Configuration = &unifi.NetworkIPv4Configuration{
+			HostIPAddress:     gateway,
+			PrefixLength:      subnet.Bits(),
+			DHCPConfiguration: &unifi.NetworkDHCPConfiguration{Mode: "NONE"},
+		}
+
+		if isKnown(m.DHCPStart) {
+			dhcp := &unifi.NetworkDHCPConfiguration{
+				Mode: "SERVER",
+				IPAddressRange: &unifi.NetworkIPRange{
+					Start: m.DHCPStart.ValueString(),
+					Stop:  m.DHCPStop.ValueString(),
+				},
+				DomainName: m.DomainName.ValueString(),
+			}
+			if !m.DHCPLeaseTime.IsNull() && !m.DHCPLeaseTime.IsUnknown() {
+				dhcp.LeaseTimeSeconds = int(m.DHCPLeaseTime.ValueInt64())
+			}
+			if !m.DHCPDNSServers.IsNull() {
+				diags.Append(m.DHCPDNSServers.ElementsAs(ctx, &dhcp.DNSServerIPAddressesOverride, false)...)
+			}
+			network.IPv4Configuration.DHCPConfiguration = dhcp
+		}
+	}
+
+	if isKnown(m.IPv6Mode) {
+		network.IPv6Configuration = &unifi.NetworkIPv6Configuration{InterfaceType: m.IPv6Mode.ValueString()}
+	}
+
+	return network, diags
+}
+
+func networkToModel(ctx context.Context, n *unifi.Network, m *NetworkResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(n.ID)
+	m.Name = types.StringValue(n.Name)
+	m.VlanID = types.Int64Value(int64(n.VlanID))
+	m.Management = types.StringValue(n.Management)
+	m.Enabled = types.BoolValue(n.Enabled)
+	m.IsolationEnabled = types.BoolValue(n.IsolationEnabled)
+	m.ZoneID = stringOrNull(n.ZoneID)
+
+	m.Subnet = types.StringNull()
+	m.Gateway = types.StringNull()
+	m.DHCPStart = types.StringNull()
+	m.DHCPStop = types.StringNull()
+	m.DHCPLeaseTime = types.Int64Null()
+	m.DomainName = types.StringNull()
+	m.DHCPDNSServers = types.ListNull(types.StringType)
+
+	if ipv4 := n.IPv4Configuration; ipv4 != nil && ipv4.HostIPAddress != "" {
+		if subnet, ok := networkSubnet(*n); ok {
+			m.Subnet = types.StringValue(subnet.String())
+			m.Gateway = types.StringValue(ipv4.HostIPAddress)
+		}
+
+		if dhcp := ipv4.DHCPConfiguration; dhcp != nil && dhcp.Mode == "SERVER" {
+			if dhcp.IPAddressRange != nil {
+				m.DHCPStart = types.StringValue(dhcp.IPAddressRange.Start)
+				m.DHCPStop = types.StringValue(dhcp.IPAddressRange.Stop)
+			}
+			if dhcp.LeaseTimeSeconds > 0 {
+				m.DHCPLeaseTime = types.Int64Value(int64(dhcp.LeaseTimeSeconds))
+			}
+			m.DomainName = stringOrNull(dhcp.DomainName)
+			if len(dhcp.DNSServerIPAddressesOverride) > 0 {
+				list, d := types.ListValueFrom(ctx, types.StringType, dhcp.DNSServerIPAddressesOverride)
+				diags.Append(d...)
+				m.DHCPDNSServers = list
+			}
+		}
+	}
+
+	m.IPv6Mode = types.StringNull()
+	if n.IPv6Configuration != nil && n.IPv6Configuration.InterfaceType != "" {
+		m.IPv6Mode = types.StringValue(n.IPv6Configuration.InterfaceType)
+	}
+
+	return diags
+}
+
+func stringOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+func baseNetworkModel() NetworkResourceModel {
+	return NetworkResourceModel{
+		ID:               types.StringUnknown(),
+		Name:             types.StringValue("IoT"),
+		VlanID:           types.Int64Value(30),
+		Management:       types.StringValue("GATEWAY"),
+		Enabled:          types.BoolValue(true),
+		Subnet:           types.StringValue("10.0.30.0/24"),
+		Gateway:          types.StringUnknown(),
+		DHCPStart:        types.StringValue("10.0.30.100"),
+		DHCPStop:         types.StringValue("10.0.30.200"),
+		DHCPLeaseTime:    types.Int64Value(3600),
+		DHCPDNSServers:   types.ListValueMust(types.StringType, []attr.Value{types.StringValue("1.1.1.1")}),
+		DomainName:       types.StringValue("iot.lan"),
+		IPv6Mode:         types.StringNull(),
+		IsolationEnabled: types.BoolValue(true),
+		ZoneID:           types.StringUnknown(),
+	}
+}
+
+func TestNetworkFromModel(t *testing.T) {
+	network, diags := networkFromModel(context.Background(), baseNetworkModel())
+	if diags.HasError() {
+		t.Fatalf("networkFromModel: %v", diags)
+	}
+
+	ipv4 := network.IPv4Configuration
+	if ipv4 == nil {
+		t.Fatal("expected IPv4 configuration")
+	}
+	if ipv4.HostIPAddress != "10.0.30.1" || ipv4.PrefixLength != 24 {
+		t.Errorf("expected default gateway 10.0.30.1/24, got %s/%d", ipv4.HostIPAddress, ipv4.PrefixLength)
+	}
+	dhcp := ipv4.DHCPConfiguration
+	if dhcp.Mode != "SERVER" || dhcp.IPAddressRange.Start != "10.0.30.100" || dhcp.LeaseTimeSeconds != 3600 {
+		t.Errorf("unexpected DHCP configuration: %+v", dhcp)
+	}
+	if len(dhcp.DNSServerIPAddressesOverride) != 1 || dhcp.DomainName != "iot.lan" {
+		t.Errorf("unexpected DHCP options: %+v", dhcp)
+	}
+	if network.IPv6Configuration != nil {
+		t.Error("expected no IPv6 configuration when ipv6_mode is unset")
+	}
+	if !network.IsolationEnabled {
+		t.Error("expected isolation to be enabled")
+	}
+}
+
+func TestNetworkFromModel_NoDHCP(t *testing.T) {
+	m := baseNetworkModel()
+	m.Gateway = types.StringValue("10.0.30.254")
+	m.DHCPStart = types.StringNull()
+	m.DHCPStop = types.StringNull()
+	m.DHCPLeaseTime = types.Int64Unknown()
+	m.DHCPDNSServers = types.ListNull(types.StringType)
+	m.DomainName = types.StringNull()
+
+	network, diags := networkFromModel(context.Background(), m)
+	if diags.HasError() {
+		t.Fatalf("networkFromModel: %v", diags)
+	}
+	if network.IPv4Configuration.HostIPAddress != "10.0.30.254" {
+		t.Errorf("expected configured gateway, got %s", network.IPv4Configuration.HostIPAddress)
+	}
+	if network.IPv4Configuration.DHCPConfiguration.Mode != "NONE" {
+		t.Errorf("expected DHCP mode NONE, got %s", network.IPv4Configuration.DHCPConfiguration.Mode)
+	}
+}
+
+func TestNetworkToModel(t *testing.T) {
+	n := &unifi.Network{
+		ID:         "net-9",
+		Name:       "IoT",
+		VlanID:     30,
+		Management: "GATEWAY",
+		Enabled:    true,
+		ZoneID:     "zone-iot",
+		IPv4Configuration: &unifi.NetworkIPv4Configuration{
+			HostIPAddress: "10.0.30.1",
+			PrefixLength:  24,
+			DHCPConfiguration: &unifi.NetworkDHCPConfiguration{
+				Mode:             "SERVER",
+				IPAddressRange:   &unifi.NetworkIPRange{Start: "10.0.30.100", Stop: "10.0.30.200"},
+				LeaseTimeSeconds: 86400,
+			},
+		},
+		IPv6Configuration: &unifi.NetworkIPv6Configuration{InterfaceType: "PREFIX_DELEGATION"},
+	}
+
+	var m NetworkResourceModel
+	if diags := networkToModel(context.Background(), n, &m); diags.HasError() {
+		t.Fatalf("networkToModel: %v", diags)
+	}
+
+	if m.Subnet.ValueString() != "10.0.30.0/24" || m.Gateway.ValueString() != "10.0.30.1" {
+		t.Errorf("expected subnet 10.0.30.0/24 via 10.0.30.1, got %s via %s", m.Subnet.ValueString(), m.Gateway.ValueString())
+	}
+	if m.DHCPStart.ValueString() != "10.0.30.100" || m.DHCPLeaseTime.ValueInt64() != 86400 {
+		t.Errorf("unexpected DHCP state: start=%s lease=%d", m.DHCPStart.ValueString(), m.DHCPLeaseTime.ValueInt64())
+	}
+	if !m.DHCPDNSServers.IsNull() || !m.DomainName.IsNull() {
+		t.Error("expected unset DHCP options to be null")
+	}
+	if m.IPv6Mode.ValueString() != "PREFIX_DELEGATION" {
+		t.Errorf("expected ipv6_mode PREFIX_DELEGATION, got %s", m.IPv6Mode.ValueString())
+	}
+	if m.ZoneID.ValueString() != "zone-iot" {
+		t.Errorf("expected zone_id zone-iot, got %s", m.ZoneID.ValueString())
+	}
+}
+
+func TestValidateNetworkAddresses(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*NetworkResourceModel)
+		wantPath path.Path
+	}{
+		{"valid", func(m *NetworkResourceModel) {}, path.Empty()},
+		{"gateway outside subnet", func(m *NetworkResourceModel) { m.Gateway = types.StringValue("10.0.31.1") }, path.Root("gateway")},
+		{"dhcp start outside subnet", func(m *NetworkResourceModel) { m.DHCPStart = types.StringValue("10.0.40.1") }, path.Root("dhcp_start")},
+		{"reversed range", func(m *NetworkResourceModel) {
+			m.DHCPStart = types.StringValue("10.0.30.200")
+			m.DHCPStop = types.StringValue("10.0.30.100")
+		}, path.Root("dhcp_stop")},
+		{"unknown subnet skipped", func(m *NetworkResourceModel) {
+			m.Subnet = types.StringUnknown()
+			m.Gateway = types.StringValue("192.168.1.1")
+		}, path.Empty()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := baseNetworkModel()
+			tt.modify(&m)
+			diags := validateNetworkAddresses(m)
+			if tt.wantPath.Equal(path.Empty()) {
+				if diags.HasError() {
+					t.Errorf("unexpected errors: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %v", diags)
+			}
+			if d, ok := diags[0].(interface{ Path() path.Path }); !ok || !d.Path().Equal(tt.wantPath) {
+				t.Errorf("expected error on %s, got %v", tt.wantPath, diags[0])
+			}
+		})
+	}
+}
+
+func TestIPv4PrefixValidator(t *testing.T) {
+	tests := map[string]bool{
+		"10.0.30.0/24": false,
+		"10.0.30.1/24": true,
+		"10.0.30.0/31": true,
+		"fd00::/64":    true,
+		"not-a-cidr":   true,
+	}
+	for value, wantErr := range tests {
+		resp := &validator.StringResponse{}
+		ipv4PrefixValidator{}.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("subnet"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+		if resp.Diagnostics.HasError() != wantErr {
+			t.Errorf("%q: got error=%v, want %v", value, resp.Diagnostics.HasError(), wantErr)
+		}
+	}
+}
+
+func TestIPv4AddressValidator(t *testing.T) {
+	tests := map[string]bool{
+		"10.0.30.1":    false,
+		"10.0.30.":     true,
+		"fd00::1":      true,
+		"10.0.30.1/24": true,
+	}
+	for value, wantErr := range tests {
+		resp := &validator.StringResponse{}
+		ipv4AddressValidator{}.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("gateway"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+		if resp.Diagnostics.HasError() != wantErr {
+			t.Errorf("%q: got error=%v, want %v", value, resp.Diagnostics.HasError(), wantErr)
+		}
+	}
+}
+
+func TestDefaultGatewayFollowsSubnet(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&NetworkResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	prior := baseNetworkModel()
+	prior.ID = types.StringValue("net-1")
+	prior.Gateway = types.StringValue("10.0.30.1")
+	prior.ZoneID = types.StringValue("zone-1")
+	prior.Site = types.StringValue("site-1")
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	state.Set(ctx, &prior)
+
+	for subnet, wantUnknown := range map[string]bool{"10.0.30.0/24": false, "10.0.40.0/24": true} {
+		planned := prior
+		planned.Subnet = types.StringValue(subnet)
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw.Copy()}
+		plan.Set(ctx, &planned)
+
+		req := planmodifier.StringRequest{
+			Path:        path.Root("gateway"),
+			State:       state,
+			Plan:        plan,
+			StateValue:  prior.Gateway,
+			ConfigValue: types.StringNull(),
+			PlanValue:   prior.Gateway,
+		}
+		resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+		defaultGatewayFollowsSubnet{}.PlanModifyString(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected errors: %v", subnet, resp.Diagnostics)
+		}
+		if resp.PlanValue.IsUnknown() != wantUnknown {
+			t.Errorf("%s: expected unknown=%v, got %v", subnet, wantUnknown, resp.PlanValue)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var (
+	_ datasource.DataSource              = &NetworksDataSource{}
+	_ datasource.DataSourceWithConfigure = &NetworksDataSource{}
+)
+
+// NetworksDataSource lists the site's networks, optionally filtered.
+type NetworksDataSource struct {
+	client *unifi.Client
+}
+
+type NetworksDataSourceModel struct {
+	NameRegex  types.String             `tfsdk:"name_regex"`
+	VlanIDMin  types.Int64              `tfsdk:"vlan_id_min"`
+	VlanIDMax  types.Int64              `tfsdk:"vlan_id_max"`
+	Management types.String             `tfsdk:"management"`
+	Networks   []NetworkDataSourceModel `tfsdk:"networks"`
+	Site       types.String             `tfsdk:"site"`
+}
+
+func NewNetworksDataSource() datasource.DataSource {
+	return &NetworksDataSource{}
+}
+
+func (d *NetworksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_networks"
+}
+
+func (d *NetworksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the site's networks. All filters are optional and combined with AND.",
+		Attributes: map[string]schema.Attribute{
+			"site": sitescope.DataSourceAttribute(),
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return networks whose name matches this regular expression (RE2 syntax).",
+				Validators: []validator.String{
+					regexValidator{},
+				},
+			},
+			"vlan_id_min": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only return networks with a VLAN ID of at least this value.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 4094),
+				},
+			},
+			"vlan_id_max": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only return networks with a VLAN ID of at most this value.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 4094),
+					int64validator.AtLeastSumOf(path.MatchRoot("vlan_id_min")),
+				},
+			},
+			"management": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return networks with this management type: `GATEWAY`, `SWITCH` or `UNMANAGED`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("GATEWAY", "SWITCH", "UNMANAGED"),
+				},
+			},
+			"networks": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching networks, in controller order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: networkDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *NetworksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*unifi.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *unifi.Client, got %T", req.ProviderData))
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NetworksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NetworksDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.DataSourceContext(ctx, d.client, data.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filter networkFilter
+	if !data.NameRegex.IsNull() {
+		re, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+			return
+		}
+		filter.nameRegex = re
+	}
+	if !data.VlanIDMin.IsNull() {
+		v := int(data.VlanIDMin.ValueInt64())
+		filter.vlanMin = &v
+	}
+	if !data.VlanIDMax.IsNull() {
+		v := int(data.VlanIDMax.ValueInt64())
+		filter.vlanMax = &v
+	}
+	filter.management = data.Management.ValueString()
+
+	networks, err := d.client.ListNetworks(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing networks", err.Error())
+		return
+	}
+
+	data.Networks = []NetworkDataSourceModel{}
+	for _, network := range networks {
+		if !filter.matches(network) {
+			continue
+		}
+		var m NetworkDataSourceModel
+		resp.Diagnostics.Append(networkToModel(ctx, &network, &m)...)
+		m.Site = data.Site
+		data.Networks = append(data.Networks, m)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// networkFilter selects networks for unifi_networks. Unset fields match all.
+type networkFilter struct {
+	nameRegex  *regexp.Regexp
+	vlanMin    *int
+	vlanMax    *int
+	management string
+}
+
+func (f networkFilter) matches(n unifi.Network) bool {
+	if f.nameRegex != nil && !f.nameRegex.MatchString(n.Name) {
+		return false
+	}
+	if f.vlanMin != nil && n.VlanID < *f.vlanMin {
+		return false
+	}
+	if f.vlanMax != nil && n.VlanID > *f.vlanMax {
+		return false
+	}
+	if f.management != "" && n.Management != f.management {
+		return false
+	}
+	return true
+}
@@ -2,12 +2,14 @@ package provider
 
 import (
 	"context"
-	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/firewall"
 	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/fixedip"
@@ -25,6 +27,13 @@ type UnifiProviderModel struct {
 	Password types.String `tfsdk:"password"`
 	SiteID   types.String `tfsdk:"site_id"`
 	Insecure types.Bool   `tfsdk:"insecure"`
+
+	Profile         types.String `tfsdk:"profile"`
+	CredentialsFile types.String `tfsdk:"credentials_file"`
+
+	MaxRetries   types.Int64 `tfsdk:"max_retries"`
+	RetryMaxWait types.Int64 `tfsdk:"retry_max_wait"`
+	CacheTTL     types.Int64 `tfsdk:"cache_ttl"`
 }
 
 func (p *UnifiProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -36,24 +45,59 @@ func (p *UnifiProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Required: true,
+				Optional:            true,
+				MarkdownDescription: "URL of the UniFi Network integration API, e.g. `https://192.168.1.1/proxy/network/integration`. Can also be set with `UNIFI_HOST` or in a credentials file profile.",
 			},
 			"api_key": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "API key. Can also be set with `UNIFI_API_KEY` or in a credentials file profile. Conflicts with `username` and `password`.",
 			},
 			"username": schema.StringAttribute{
-				Optional: true,
+				Optional:            true,
+				MarkdownDescription: "Username of a local controller account, for consoles without API keys. Can also be set with `UNIFI_USERNAME` or in a credentials file profile.",
 			},
 			"password": schema.StringAttribute{
-				Optional:  true,
-				Sensitive: true,
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Password for `username`. Can also be set with `UNIFI_PASSWORD` or in a credentials file profile.",
 			},
 			"site_id": schema.StringAttribute{
-				Required: true,
+				Optional:            true,
+				MarkdownDescription: "The default site: a site UUID, name or internal reference, or `auto` for the only site of the controller. Can also be set with `UNIFI_SITE` or in a credentials file profile.",
 			},
 			"insecure": schema.BoolAttribute{
-				Optional: true,
+				Optional:            true,
+				MarkdownDescription: "Skip TLS certificate verification. Can also be set with `UNIFI_INSECURE` or in a credentials file profile.",
+			},
+			"profile": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Credentials file profile to read settings from. Defaults to `UNIFI_PROFILE`, then `default`.",
+			},
+			"credentials_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path of the credentials file. Defaults to `UNIFI_CREDENTIALS_FILE`, then `~/.unifi/credentials`.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of retries for requests that fail with a transient controller error (429, 502, 503, 504 or a dropped connection). Defaults to `4`; `0` disables retrying.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of seconds to wait between two retries, including waits requested by a `Retry-After` header. Defaults to `30`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"cache_ttl": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of seconds list responses (zones, networks, policies, ...) are cached per site, so that resources and data sources read in one run share API calls. Defaults to `120`; `0` disables caching.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 		},
 	}
@@ -67,59 +111,44 @@ func (p *UnifiProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
-	hasAPIKey := !data.APIKey.IsNull() && !data.APIKey.IsUnknown() && data.APIKey.ValueString() != ""
-	hasUsername := !data.Username.IsNull() && !data.Username.IsUnknown() && data.Username.ValueString() != ""
-	hasPassword := !data.Password.IsNull() && !data.Password.IsUnknown() && data.Password.ValueString() != ""
-
-	if hasAPIKey && (hasUsername || hasPassword) {
-		resp.Diagnostics.AddError(
-			"Conflicting authentication",
-			"Specify either 'api_key' or 'username'+'password', not both.",
-		)
-		return
-	}
-	if !hasAPIKey && !hasUsername {
-		resp.Diagnostics.AddError(
-			"Missing authentication",
-			"Either 'api_key' or both 'username' and 'password' must be provided.",
-		)
-		return
-	}
-	if hasUsername && !hasPassword {
-		resp.Diagnostics.AddError(
-			"Missing password",
-			"'password' is required when 'username' is specified.",
-		)
+	cfg, diags := resolveConfig(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	hasAPIKey := cfg.APIKey != ""
+
+	retry := retryPolicy(data)
 
 	// Create the appropriate client for site discovery
 	var discoveryClient *unifi.Client
 	if hasAPIKey {
-		discoveryClient = unifi.NewClient(data.Host.ValueString(), data.APIKey.ValueString(), "", data.Insecure.ValueBool())
+		discoveryClient = unifi.NewClient(cfg.Host, cfg.APIKey, "", cfg.Insecure)
+		discoveryClient.Retry = retry
 	} else {
 		var err error
 		discoveryClient, err = unifi.NewClientWithCredentials(
-			data.Host.ValueString(),
-			data.Username.ValueString(),
-			data.Password.ValueString(),
+			ctx,
+			cfg.Host,
+			cfg.Username,
+			cfg.Password,
 			"",
-			data.Insecure.ValueBool(),
+			cfg.Insecure,
 		)
 		if err != nil {
 			resp.Diagnostics.AddError("Authentication failed", err.Error())
 			return
 		}
+		discoveryClient.Retry = retry
 	}
 
-	sites, err := discoveryClient.ListSites()
+	sites, err := discoveryClient.ListSites(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Error listing sites for discovery", err.Error())
 		return
 	}
 
-	siteInput := data.SiteID.ValueString()
-	discoveredSite, err := discoverSite(sites, siteInput)
+	discoveredSite, err := unifi.DiscoverSite(sites, cfg.Site)
 	if err != nil {
 		resp.Diagnostics.AddError("Site discovery failed", err.Error())
 		return
@@ -128,13 +157,15 @@ func (p *UnifiProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	// Create the final client with the discovered site ID
 	var client *unifi.Client
 	if hasAPIKey {
-		client = unifi.NewClient(data.Host.ValueString(), data.APIKey.ValueString(), discoveredSite.ID, data.Insecure.ValueBool())
+		client = unifi.NewClient(cfg.Host, cfg.APIKey, discoveredSite.ID, cfg.Insecure)
+		client.Retry = retry
 	} else {
 		// Reuse the discovery client — just update the site ID to avoid a second login
 		discoveryClient.SiteID = discoveredSite.ID
 		client = discoveryClient
 	}
 	client.SiteReference = discoveredSite.InternalReference
+	client.CacheTTL = cacheTTL(data)
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
@@ -142,37 +173,49 @@ func (p *UnifiProvider) Configure(ctx context.Context, req provider.ConfigureReq
 func (p *UnifiProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		firewall.NewFirewallPolicyResource,
+		firewall.NewFirewallPolicyOrderResource,
+		firewall.NewFirewallZoneResource,
+		firewall.NewTrafficMatchingListResource,
 		firewall.NewDNSPolicyResource,
 		fixedip.NewFixedIPResource,
+		NewNetworkResource,
 	}
 }
 
 func (p *UnifiProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		firewall.NewFirewallZoneDataSource,
+		firewall.NewFirewallZonesDataSource,
 		NewNetworkDataSource,
+		NewNetworksDataSource,
+		NewDPIApplicationDataSource,
+		NewVPNServerDataSource,
+		NewSiteToSiteVPNTunnelDataSource,
+		NewSiteDataSource,
+		NewSitesDataSource,
 	}
 }
 
-// discoverSite resolves a site input (UUID, name, internal reference, or "auto")
-// to a concrete Site from the list of available sites.
-func discoverSite(sites []unifi.Site, siteInput string) (unifi.Site, error) {
-	if siteInput == "auto" {
-		if len(sites) == 1 {
-			return sites[0], nil
-		} else if len(sites) == 0 {
-			return unifi.Site{}, fmt.Errorf("auto-discovery failed: no sites were found")
-		}
-		return unifi.Site{}, fmt.Errorf("auto-discovery failed: multiple sites exist, please specify site name or UUID")
+// retryPolicy builds the client retry policy from the provider configuration,
+// falling back to the client defaults for unset attributes.
+func retryPolicy(data UnifiProviderModel) unifi.RetryPolicy {
+	policy := unifi.DefaultRetryPolicy()
+	if !data.MaxRetries.IsNull() && !data.MaxRetries.IsUnknown() {
+		policy.MaxRetries = int(data.MaxRetries.ValueInt64())
 	}
-
-	for _, s := range sites {
-		if s.ID == siteInput || s.Name == siteInput || s.InternalReference == siteInput {
-			return s, nil
-		}
+	if !data.RetryMaxWait.IsNull() && !data.RetryMaxWait.IsUnknown() {
+		policy.MaxWait = time.Duration(data.RetryMaxWait.ValueInt64()) * time.Second
+		policy.MinWait = min(policy.MinWait, policy.MaxWait)
 	}
+	return policy
+}
 
-	return unifi.Site{}, fmt.Errorf("could not find site matching: %s", siteInput)
+// cacheTTL returns the configured cache TTL, or the client default.
+func cacheTTL(data UnifiProviderModel) time.Duration {
+	if data.CacheTTL.IsNull() || data.CacheTTL.IsUnknown() {
+		return unifi.DefaultCacheTTL
+	}
+	return time.Duration(data.CacheTTL.ValueInt64()) * time.Second
 }
 
 func New(version string) func() provider.Provider {
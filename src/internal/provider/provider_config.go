@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Settings that can come from the provider block, the environment or a
+// credentials file profile, in that order of precedence.
+const (
+	settingHost     = "host"
+	settingAPIKey   = "api_key"
+	settingUsername = "username"
+	settingPassword = "password"
+	settingSite     = "site"
+	settingInsecure = "insecure"
+)
+
+// settingEnv maps each setting to its environment variable.
+var settingEnv = map[string]string{
+	settingHost:     "UNIFI_HOST",
+	settingAPIKey:   "UNIFI_API_KEY",
+	settingUsername: "UNIFI_USERNAME",
+	settingPassword: "UNIFI_PASSWORD",
+	settingSite:     "UNIFI_SITE",
+	settingInsecure: "UNIFI_INSECURE",
+}
+
+const (
+	envProfile         = "UNIFI_PROFILE"
+	envCredentialsFile = "UNIFI_CREDENTIALS_FILE"
+	defaultProfile     = "default"
+)
+
+// providerConfig is the provider configuration after merging all sources.
+type providerConfig struct {
+	Host     string
+	APIKey   string
+	Username string
+	Password string
+	Site     string
+	Insecure bool
+}
+
+// configSource is one source of settings. Empty values count as unset.
+type configSource struct {
+	name     string
+	settings map[string]string
+}
+
+// resolveConfig merges the provider block with the UNIFI_* environment
+// variables and the selected credentials file profile. Each setting comes from
+// the first source that sets it, except that api_key and username/password are
+// taken as a pair: the first source that sets either one decides how the
+// provider authenticates, and the other style is ignored in later sources.
+func resolveConfig(data UnifiProviderModel) (providerConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	sources := []configSource{hclSource(data), envSource()}
+	profile, err := credentialsProfile(data)
+	if err != nil {
+		diags.AddError("Error reading credentials file", err.Error())
+		return providerConfig{}, diags
+	}
+	if profile != nil {
+		sources = append(sources, *profile)
+	}
+
+	// The first source with credentials decides how the provider authenticates.
+	var cfg providerConfig
+	useAPIKey, useLogin := false, false
+	for _, src := range sources {
+		hasAPIKey := src.settings[settingAPIKey] != ""
+		hasLogin := src.settings[settingUsername] != "" || src.settings[settingPassword] != ""
+		// Later sources may set both; only the style already chosen is used.
+		if !useAPIKey && !useLogin && hasAPIKey && hasLogin {
+			diags.AddError(
+				"Conflicting authentication",
+				fmt.Sprintf("Specify either 'api_key' or 'username'+'password' in the %s, not both.", src.name),
+			)
+			return providerConfig{}, diags
+		}
+		switch {
+		case !useLogin && !useAPIKey && hasAPIKey:
+			useAPIKey = true
+			cfg.APIKey = src.settings[settingAPIKey]
+		case !useAPIKey && hasLogin:
+			useLogin = true
+			cfg.Username = first(cfg.Username, src.settings[settingUsername])
+			cfg.Password = first(cfg.Password, src.settings[settingPassword])
+		}
+	}
+
+	insecure := ""
+	for _, src := range sources {
+		cfg.Host = first(cfg.Host, src.settings[settingHost])
+		cfg.Site = first(cfg.Site, src.settings[settingSite])
+		if insecure == "" && src.settings[settingInsecure] != "" {
+			insecure = src.settings[settingInsecure]
+			cfg.Insecure, err = strconv.ParseBool(insecure)
+			if err != nil {
+				diags.AddError("Invalid insecure setting", fmt.Sprintf("The insecure setting in the %s must be true or false, got %q.", src.name, insecure))
+				return providerConfig{}, diags
+			}
+		}
+	}
+
+	if cfg.Host == "" {
+		diags.AddAttributeError(path.Root("host"), "Missing host",
+			"Set 'host' in the provider block, the UNIFI_HOST environment variable or a credentials file profile.")
+	}
+	if cfg.Site == "" {
+		diags.AddAttributeError(path.Root("site_id"), "Missing site",
+			"Set 'site_id' in the provider block, the UNIFI_SITE environment variable or a credentials file profile. Use \"auto\" to pick the only site of the controller.")
+	}
+	if cfg.APIKey == "" && cfg.Username == "" {
+		// A password alone is not enough either.
+		diags.AddError(
+			"Missing authentication",
+			"Either 'api_key' or both 'username' and 'password' must be provided, in the provider block, the UNIFI_* environment variables or a credentials file profile.",
+		)
+	} else if cfg.Username != "" && cfg.Password == "" {
+		diags.AddError(
+			"Missing password",
+			"'password' is required when 'username' is specified.",
+		)
+	}
+	return cfg, diags
+}
+
+func hclSource(data UnifiProviderModel) configSource {
+	src := configSource{name: "provider configuration", settings: map[string]string{
+		settingHost:     data.Host.ValueString(),
+		settingAPIKey:   data.APIKey.ValueString(),
+		settingUsername: data.Username.ValueString(),
+		settingPassword: data.Password.ValueString(),
+		settingSite:     data.SiteID.ValueString(),
+	}}
+	if !data.Insecure.IsNull() && !data.Insecure.IsUnknown() {
+		src.settings[settingInsecure] = strconv.FormatBool(data.Insecure.ValueBool())
+	}
+	return src
+}
+
+func envSource() configSource {
+	src := configSource{name: "environment", settings: map[string]string{}}
+	for setting, env := range settingEnv {
+		src.settings[setting] = os.Getenv(env)
+	}
+	return src
+}
+
+// credentialsProfile reads the selected profile of the credentials file. The
+// profile comes from the profile attribute, UNIFI_PROFILE or "default", and
+// the file from the credentials_file attribute, UNIFI_CREDENTIALS_FILE or
+// ~/.unifi/credentials. A missing default file or default profile is not an
+// error; it returns nil.
+func credentialsProfile(data UnifiProviderModel) (*configSource, error) {
+	name := first(stringValue(data.Profile), os.Getenv(envProfile))
+	explicitProfile := name != ""
+	if !explicitProfile {
+		name = defaultProfile
+	}
+
+	file := first(stringValue(data.CredentialsFile), os.Getenv(envCredentialsFile))
+	explicitFile := file != ""
+	if !explicitFile {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			if explicitProfile {
+				return nil, fmt.Errorf("profile %q: cannot locate the default credentials file: %w", name, err)
+			}
+			return nil, nil
+		}
+		file = filepath.Join(home, ".unifi", "credentials")
+	}
+
+	profiles, err := parseCredentialsFile(file)
+	if errors.Is(err, fs.ErrNotExist) && !explicitFile && !explicitProfile {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	settings, ok := profiles[name]
+	if !ok {
+		if explicitProfile {
+			return nil, fmt.Errorf("profile %q not found in %s", name, file)
+		}
+		return nil, nil
+	}
+	return &configSource{name: fmt.Sprintf("profile %q of %s", name, file), settings: settings}, nil
+}
+
+// parseCredentialsFile parses an INI-style credentials file: [profile]
+// headers followed by "key = value" lines. Blank lines and lines starting
+// with # or ; are ignored.
+func parseCredentialsFile(file string) (map[string]map[string]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := profiles[name]; ok {
+				return nil, fmt.Errorf("%s:%d: duplicate profile %q", file, lineNo, name)
+			}
+			current = map[string]string{}
+			profiles[name] = current
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected a [profile] header or key = value", file, lineNo)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("%s:%d: setting outside of a [profile] section", file, lineNo)
+		}
+		key = strings.TrimSpace(key)
+		if _, known := settingEnv[key]; !known {
+			return nil, fmt.Errorf("%s:%d: unknown setting %q", file, lineNo, key)
+		}
+		current[key] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func stringValue(v types.String) string {
+	if v.IsNull() || v.IsUnknown() {
+		return ""
+	}
+	return v.ValueString()
+}
+
+// first returns the first non-empty value.
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// isolateConfig clears the UNIFI_* environment and points HOME at an empty
+// directory, which it returns.
+func isolateConfig(t *testing.T) string {
+	t.Helper()
+	for _, env := range settingEnv {
+		t.Setenv(env, "")
+	}
+	t.Setenv(envProfile, "")
+	t.Setenv(envCredentialsFile, "")
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	return home
+}
+
+func writeCredentials(t *testing.T, file, content string) string {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func emptyModel() UnifiProviderModel {
+	return UnifiProviderModel{
+		Host:            types.StringNull(),
+		APIKey:          types.StringNull(),
+		Username:        types.StringNull(),
+		Password:        types.StringNull(),
+		SiteID:          types.StringNull(),
+		Insecure:        types.BoolNull(),
+		Profile:         types.StringNull(),
+		CredentialsFile: types.StringNull(),
+	}
+}
+
+func mustResolve(t *testing.T, data UnifiProviderModel) providerConfig {
+	t.Helper()
+	cfg, diags := resolveConfig(data)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	return cfg
+}
+
+func hasError(diags diag.Diagnostics, summary string) bool {
+	for _, d := range diags.Errors() {
+		if d.Summary() == summary {
+			return true
+		}
+	}
+	return false
+}
+
+func TestResolveConfig_ProviderBlock(t *testing.T) {
+	isolateConfig(t)
+	data := emptyModel()
+	data.Host = types.StringValue("https://hcl")
+	data.APIKey = types.StringValue("hcl-key")
+	data.SiteID = types.StringValue("default")
+	data.Insecure = types.BoolValue(true)
+
+	cfg := mustResolve(t, data)
+	want := providerConfig{Host: "https://hcl", APIKey: "hcl-key", Site: "default", Insecure: true}
+	if cfg != want {
+		t.Errorf("expected %+v, got %+v", want, cfg)
+	}
+}
+
+func TestResolveConfig_Environment(t *testing.T) {
+	isolateConfig(t)
+	t.Setenv("UNIFI_HOST", "https://env")
+	t.Setenv("UNIFI_USERNAME", "admin")
+	t.Setenv("UNIFI_PASSWORD", "secret")
+	t.Setenv("UNIFI_SITE", "auto")
+	t.Setenv("UNIFI_INSECURE", "true")
+
+	cfg := mustResolve(t, emptyModel())
+	want := providerConfig{Host: "https://env", Username: "admin", Password: "secret", Site: "auto", Insecure: true}
+	if cfg != want {
+		t.Errorf("expected %+v, got %+v", want, cfg)
+	}
+}
+
+func TestResolveConfig_ProviderBlockOverridesEnvironment(t *testing.T) {
+	isolateConfig(t)
+	t.Setenv("UNIFI_HOST", "https://env")
+	t.Setenv("UNIFI_SITE", "env-site")
+	t.Setenv("UNIFI_API_KEY", "env-key")
+	t.Setenv("UNIFI_INSECURE", "true")
+	data := emptyModel()
+	data.Host = types.StringValue("https://hcl")
+	data.Insecure = types.BoolValue(false)
+
+	cfg := mustResolve(t, data)
+	want := providerConfig{Host: "https://hcl", APIKey: "env-key", Site: "env-site"}
+	if cfg != want {
+		t.Errorf("expected %+v, got %+v", want, cfg)
+	}
+}
+
+func TestResolveConfig_DefaultCredentialsFile(t *testing.T) {
+	home := isolateConfig(t)
+	writeCredentials(t, filepath.Join(home, ".unifi", "credentials"), `
+# lab controller
+[default]
+host     = https://file
+api_key  = "file-key"
+site     = default
+insecure = true
+
+[office]
+host = https://office
+`)
+
+	cfg := mustResolve(t, emptyModel())
+	want := providerConfig{Host: "https://file", APIKey: "file-key", Site: "default", Insecure: true}
+	if cfg != want {
+		t.Errorf("expected %+v, got %+v", want, cfg)
+	}
+}
+
+func TestResolveConfig_Profile(t *testing.T) {
+	isolateConfig(t)
+	file := writeCredentials(t, filepath.Join(t.TempDir(), "creds"), `
+[default]
+host    = https://default
+api_key = default-key
+site    = default
+
+[office]
+host     = https://office
+username = admin
+password = office-secret
+site     = Office
+`)
+	t.Setenv(envCredentialsFile, file)
+
+	t.Setenv(envProfile, "office")
+	cfg := mustResolve(t, emptyModel())
+	if cfg.Host != "https://office" || cfg.Username != "admin" || cfg.APIKey != "" {
+		t.Errorf("UNIFI_PROFILE: expected the office profile, got %+v", cfg)
+	}
+
+	// The profile attribute takes precedence over UNIFI_PROFILE.
+	data := emptyModel()
+	data.Profile = types.StringValue("default")
+	cfg = mustResolve(t, data)
+	if cfg.Host != "https://default" || cfg.APIKey != "default-key" {
+		t.Errorf("profile attribute: expected the default profile, got %+v", cfg)
+	}
+
+	data.Profile = types.StringValue("lab")
+	_, diags := resolveConfig(data)
+	if !hasError(diags, "Error reading credentials file") {
+		t.Errorf("expected an error for a missing profile, got %v", diags)
+	}
+}
+
+func TestResolveConfig_EnvironmentOverridesCredentialsFile(t *testing.T) {
+	home := isolateConfig(t)
+	writeCredentials(t, filepath.Join(home, ".unifi", "credentials"), `
+[default]
+host     = https://file
+username = admin
+password = file-secret
+site     = default
+`)
+	t.Setenv("UNIFI_HOST", "https://env")
+	t.Setenv("UNIFI_PASSWORD", "env-secret")
+
+	cfg := mustResolve(t, emptyModel())
+	want := providerConfig{Host: "https://env", Username: "admin", Password: "env-secret", Site: "default"}
+	if cfg != want {
+		t.Errorf("expected %+v, got %+v", want, cfg)
+	}
+}
+
+func TestResolveConfig_AuthenticationStyleFromFirstSource(t *testing.T) {
+	home := isolateConfig(t)
+	writeCredentials(t, filepath.Join(home, ".unifi", "credentials"), `
+[default]
+api_key = file-key
+`)
+	t.Setenv("UNIFI_HOST", "https://env")
+	t.Setenv("UNIFI_SITE", "default")
+	t.Setenv("UNIFI_PASSWORD", "env-secret")
+	data := emptyModel()
+	data.Username = types.StringValue("admin")
+
+	// username in HCL picks password login; the file's api_key is ignored.
+	cfg := mustResolve(t, data)
+	if cfg.Username != "admin" || cfg.Password != "env-secret" || cfg.APIKey != "" {
+		t.Errorf("expected username and password login, got %+v", cfg)
+	}
+
+	// api_key in HCL masks username and password from the environment.
+	t.Setenv("UNIFI_USERNAME", "env-admin")
+	data = emptyModel()
+	data.APIKey = types.StringValue("hcl-key")
+	cfg = mustResolve(t, data)
+	if cfg.APIKey != "hcl-key" || cfg.Username != "" || cfg.Password != "" {
+		t.Errorf("expected API key login, got %+v", cfg)
+	}
+}
+
+func TestResolveConfig_CredentialsProfileWithBothStyles(t *testing.T) {
+	home := isolateConfig(t)
+	writeCredentials(t, filepath.Join(home, ".unifi", "credentials"), `
+[default]
+host     = https://file
+api_key  = file-key
+username = admin
+password = file-secret
+site     = default
+`)
+
+	// The provider block decides; the profile only fills in the rest.
+	data := emptyModel()
+	data.APIKey = types.StringValue("hcl-key")
+	cfg := mustResolve(t, data)
+	want := providerConfig{Host: "https://file", APIKey: "hcl-key", Site: "default"}
+	if cfg != want {
+		t.Errorf("expected %+v, got %+v", want, cfg)
+	}
+
+	data = emptyModel()
+	data.Username = types.StringValue("operator")
+	cfg = mustResolve(t, data)
+	want = providerConfig{Host: "https://file", Username: "operator", Password: "file-secret", Site: "default"}
+	if cfg != want {
+		t.Errorf("expected %+v, got %+v", want, cfg)
+	}
+
+	// Without credentials elsewhere the profile decides, and must pick one.
+	if _, diags := resolveConfig(emptyModel()); !hasError(diags, "Conflicting authentication") {
+		t.Errorf("expected a conflict within the profile, got %v", diags)
+	}
+}
+
+func TestResolveConfig_Errors(t *testing.T) {
+	isolateConfig(t)
+
+	_, diags := resolveConfig(emptyModel())
+	for _, summary := range []string{"Missing host", "Missing site", "Missing authentication"} {
+		if !hasError(diags, summary) {
+			t.Errorf("expected %q, got %v", summary, diags)
+		}
+	}
+
+	t.Setenv("UNIFI_HOST", "https://env")
+	t.Setenv("UNIFI_SITE", "default")
+	t.Setenv("UNIFI_API_KEY", "env-key")
+	t.Setenv("UNIFI_USERNAME", "admin")
+	if _, diags := resolveConfig(emptyModel()); !hasError(diags, "Conflicting authentication") {
+		t.Errorf("expected a conflict within the environment, got %v", diags)
+	}
+
+	t.Setenv("UNIFI_API_KEY", "")
+	if _, diags := resolveConfig(emptyModel()); !hasError(diags, "Missing password") {
+		t.Errorf("expected a missing password, got %v", diags)
+	}
+
+	t.Setenv("UNIFI_USERNAME", "")
+	t.Setenv("UNIFI_API_KEY", "env-key")
+	t.Setenv("UNIFI_INSECURE", "maybe")
+	if _, diags := resolveConfig(emptyModel()); !hasError(diags, "Invalid insecure setting") {
+		t.Errorf("expected an invalid UNIFI_INSECURE, got %v", diags)
+	}
+
+	t.Setenv("UNIFI_INSECURE", "")
+	t.Setenv(envCredentialsFile, filepath.Join(t.TempDir(), "missing"))
+	if _, diags := resolveConfig(emptyModel()); !hasError(diags, "Error reading credentials file") {
+		t.Errorf("expected an error for a missing explicit credentials file, got %v", diags)
+	}
+}
+
+func TestParseCredentialsFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"unknown setting": "[default]\ntoken = x\n",
+		"outside of a":    "host = https://x\n[default]\n",
+		"duplicate":       "[default]\n[default]\n",
+		"expected a":      "[default]\nhost\n",
+	}
+	for want, content := range cases {
+		file := writeCredentials(t, filepath.Join(dir, strings.ReplaceAll(want, " ", "_")), content)
+		if _, err := parseCredentialsFile(file); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected an error containing %q, got %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var (
+	_ datasource.DataSource                     = &SiteDataSource{}
+	_ datasource.DataSourceWithConfigure        = &SiteDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &SiteDataSource{}
+)
+
+// SiteDataSource looks up a site of the controller.
+type SiteDataSource struct {
+	client *unifi.Client
+}
+
+type SiteDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	InternalReference types.String `tfsdk:"internal_reference"`
+}
+
+func NewSiteDataSource() datasource.DataSource {
+	return &SiteDataSource{}
+}
+
+func (d *SiteDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_site"
+}
+
+func (d *SiteDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a site by ID, name or internal reference.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the site. Exactly one of `id`, `name` or `internal_reference` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The name of the site as shown in the UniFi UI, e.g. `Default`. Exactly one of `id`, `name` or `internal_reference` must be set.",
+			},
+			"internal_reference": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The internal reference of the site, e.g. `default`, as used in the URLs of the UniFi UI. Exactly one of `id`, `name` or `internal_reference` must be set.",
+			},
+		},
+	}
+}
+
+func (d *SiteDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name"), path.MatchRoot("internal_reference")),
+	}
+}
+
+func (d *SiteDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*unifi.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *unifi.Client, got %T", req.ProviderData))
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SiteDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SiteDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sites, err := d.client.ListSites(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing sites", err.Error())
+		return
+	}
+
+	site, err := findSite(sites, data.ID.ValueString(), data.Name.ValueString(), data.InternalReference.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Site not found", err.Error())
+		return
+	}
+
+	data = siteModel(*site)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func siteModel(site unifi.Site) SiteDataSourceModel {
+	return SiteDataSourceModel{
+		ID:                types.StringValue(site.ID),
+		Name:              types.StringValue(site.Name),
+		InternalReference: types.StringValue(site.InternalReference),
+	}
+}
+
+// findSite returns the site with the given ID or internal reference, or else
+// the single site with the given name. Exactly one of them is set.
+func findSite(sites []unifi.Site, id, name, ref string) (*unifi.Site, error) {
+	var found *unifi.Site
+	for i := range sites {
+		switch {
+		case id != "":
+			if sites[i].ID == id {
+				return &sites[i], nil
+			}
+		case ref != "":
+			if sites[i].InternalReference == ref {
+				return &sites[i], nil
+			}
+		case sites[i].Name == name:
+			if found != nil {
+				return nil, fmt.Errorf("more than one site named %s (IDs %s and %s)", name, found.ID, sites[i].ID)
+			}
+			found = &sites[i]
+		}
+	}
+	if found != nil {
+		return found, nil
+	}
+	switch {
+	case id != "":
+		return nil, fmt.Errorf("site with ID %s not found", id)
+	case ref != "":
+		return nil, fmt.Errorf("site with internal reference %s not found", ref)
+	}
+	return nil, fmt.Errorf("site with name %s not found", name)
+}
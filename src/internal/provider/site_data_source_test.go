@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+func TestFindSite(t *testing.T) {
+	sites := []unifi.Site{
+		{ID: "site-1", Name: "Default", InternalReference: "default"},
+		{ID: "site-2", Name: "Branch", InternalReference: "x7k2m9"},
+		{ID: "site-3", Name: "Branch", InternalReference: "q4w8e1"},
+	}
+
+	if got, err := findSite(sites, "", "Default", ""); err != nil || got.ID != "site-1" {
+		t.Errorf("by name: got %v, %v", got, err)
+	}
+	if got, err := findSite(sites, "site-2", "", ""); err != nil || got.InternalReference != "x7k2m9" {
+		t.Errorf("by id: got %v, %v", got, err)
+	}
+	if got, err := findSite(sites, "", "", "q4w8e1"); err != nil || got.ID != "site-3" {
+		t.Errorf("by internal reference: got %v, %v", got, err)
+	}
+	if _, err := findSite(sites, "", "Branch", ""); err == nil || !strings.Contains(err.Error(), "site-2 and site-3") {
+		t.Errorf("expected ambiguity error listing both IDs, got %v", err)
+	}
+	if _, err := findSite(sites, "", "", "lab"); err == nil || !strings.Contains(err.Error(), "internal reference lab") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var (
+	_ datasource.DataSource                     = &SiteToSiteVPNTunnelDataSource{}
+	_ datasource.DataSourceWithConfigure        = &SiteToSiteVPNTunnelDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &SiteToSiteVPNTunnelDataSource{}
+)
+
+// SiteToSiteVPNTunnelDataSource looks up a site-to-site VPN tunnel, for use in
+// a unifi_fw site_to_site_tunnel_filter.
+type SiteToSiteVPNTunnelDataSource struct {
+	client *unifi.Client
+}
+
+type SiteToSiteVPNTunnelDataSourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+	Site types.String `tfsdk:"site"`
+}
+
+func NewSiteToSiteVPNTunnelDataSource() datasource.DataSource {
+	return &SiteToSiteVPNTunnelDataSource{}
+}
+
+func (d *SiteToSiteVPNTunnelDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_site_to_site_vpn_tunnel"
+}
+
+func (d *SiteToSiteVPNTunnelDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a site-to-site VPN tunnel by name or ID.",
+		Attributes: map[string]schema.Attribute{
+			"site": sitescope.DataSourceAttribute(),
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the tunnel. Exactly one of `name` or `id` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The name of the tunnel. Exactly one of `name` or `id` must be set.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The tunnel protocol, such as `IPSEC`, `OPENVPN` or `WIREGUARD`.",
+			},
+		},
+	}
+}
+
+func (d *SiteToSiteVPNTunnelDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("id")),
+	}
+}
+
+func (d *SiteToSiteVPNTunnelDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*unifi.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *unifi.Client, got %T", req.ProviderData))
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SiteToSiteVPNTunnelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SiteToSiteVPNTunnelDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.DataSourceContext(ctx, d.client, data.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tunnels, err := d.client.ListSiteToSiteVPNTunnels(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing site-to-site VPN tunnels", err.Error())
+		return
+	}
+
+	tunnel, err := findSiteToSiteVPNTunnel(tunnels, data.ID.ValueString(), data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Site-to-site VPN tunnel not found", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(tunnel.ID)
+	data.Name = types.StringValue(tunnel.Name)
+	data.Type = types.StringValue(tunnel.Type)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findSiteToSiteVPNTunnel returns the tunnel with the given ID, or else the
+// single tunnel with the given name.
+func findSiteToSiteVPNTunnel(tunnels []unifi.SiteToSiteVPNTunnel, id, name string) (*unifi.SiteToSiteVPNTunnel, error) {
+	var found *unifi.SiteToSiteVPNTunnel
+	for i := range tunnels {
+		if id != "" {
+			if tunnels[i].ID == id {
+				return &tunnels[i], nil
+			}
+			continue
+		}
+		if tunnels[i].Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("more than one site-to-site VPN tunnel named %s (IDs %s and %s)", name, found.ID, tunnels[i].ID)
+		}
+		found = &tunnels[i]
+	}
+	if found != nil {
+		return found, nil
+	}
+	if id != "" {
+		return nil, fmt.Errorf("site-to-site VPN tunnel with ID %s not found", id)
+	}
+	return nil, fmt.Errorf("site-to-site VPN tunnel with name %s not found", name)
+}
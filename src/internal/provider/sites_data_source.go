@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var (
+	_ datasource.DataSource              = &SitesDataSource{}
+	_ datasource.DataSourceWithConfigure = &SitesDataSource{}
+)
+
+// SitesDataSource lists every site of the controller.
+type SitesDataSource struct {
+	client *unifi.Client
+}
+
+type SitesDataSourceModel struct {
+	Sites []SiteDataSourceModel `tfsdk:"sites"`
+}
+
+func NewSitesDataSource() datasource.DataSource {
+	return &SitesDataSource{}
+}
+
+func (d *SitesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sites"
+}
+
+func (d *SitesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all sites of the controller.",
+		Attributes: map[string]schema.Attribute{
+			"sites": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "All sites, in controller order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the site.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the site as shown in the UniFi UI.",
+						},
+						"internal_reference": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The internal reference of the site, e.g. `default`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SitesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*unifi.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *unifi.Client, got %T", req.ProviderData))
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SitesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	sites, err := d.client.ListSites(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing sites", err.Error())
+		return
+	}
+
+	data := SitesDataSourceModel{Sites: []SiteDataSourceModel{}}
+	for _, site := range sites {
+		data.Sites = append(data.Sites, siteModel(site))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,169 @@
+// Package sitescope implements the per-resource "site" attribute, which lets
+// one provider block manage several sites of the same controller.
+package sitescope
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+const description = "The site to manage this object in: a site UUID, name or internal reference such as `default`. Defaults to the provider's site. Moving the object to another site replaces it."
+
+// ResourceAttribute returns the site attribute of resources. client returns
+// the configured provider client, or nil before the provider is configured.
+// Moving an object to another site replaces it; respelling the same site,
+// e.g. from its UUID to its name, does not.
+func ResourceAttribute(client func() *unifi.Client) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: description,
+		PlanModifiers: []planmodifier.String{
+			replaceOnSiteChange{client: client},
+		},
+	}
+}
+
+// DataSourceAttribute returns the site attribute of data sources.
+func DataSourceAttribute() dsschema.StringAttribute {
+	return dsschema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "The site to read from: a site UUID, name or internal reference such as `default`. Defaults to the provider's site.",
+	}
+}
+
+// Context returns ctx scoped to the site in site. A null site is set to the
+// provider's default site, so that state records where the object lives. An
+// unknown site is left unknown and ctx stays on the default site; callers
+// that plan must not resolve anything against it. Failures are reported
+// against the site attribute.
+func Context(ctx context.Context, client *unifi.Client, site *types.String, diags *diag.Diagnostics) context.Context {
+	if site.IsNull() {
+		*site = types.StringValue(client.SiteID)
+		return ctx
+	}
+	if site.IsUnknown() {
+		return ctx
+	}
+
+	scoped, err := client.ForSite(ctx, site.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("site"), "Unknown site", err.Error())
+		return ctx
+	}
+	return scoped
+}
+
+// DataSourceContext is Context for data sources, which leave site unset when
+// it is not configured.
+func DataSourceContext(ctx context.Context, client *unifi.Client, site types.String, diags *diag.Diagnostics) context.Context {
+	if site.IsNull() || site.IsUnknown() {
+		return ctx
+	}
+	return Context(ctx, client, &site, diags)
+}
+
+// ImportContext splits an import ID of the form "<site>/<id>", records the
+// site in state and returns ctx scoped to it along with the bare ID. An ID
+// without a site imports from the provider's site.
+func ImportContext(ctx context.Context, client *unifi.Client, importID string, resp *resource.ImportStateResponse) (context.Context, string) {
+	site, id, ok := strings.Cut(importID, "/")
+	if !ok {
+		return ctx, importID
+	}
+	if site == "" || id == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Expected <id> or <site>/<id>, got %q.", importID))
+		return ctx, id
+	}
+
+	scoped, err := client.ForSite(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Unknown site", err.Error())
+		return ctx, id
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), site)...)
+	return scoped, id
+}
+
+// replaceOnSiteChange plans the provider's site for new objects and keeps the
+// prior site when none is configured, and requires replacement when the
+// configured site resolves to a different site than the one in state.
+type replaceOnSiteChange struct {
+	client func() *unifi.Client
+}
+
+func (m replaceOnSiteChange) Description(ctx context.Context) string {
+	return "Defaults to the provider's site, keeps the prior site when none is configured and replaces the object when it moves to another site."
+}
+
+func (m replaceOnSiteChange) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m replaceOnSiteChange) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// New objects go to the provider's site unless configured otherwise, so
+	// that the site is known at plan time.
+	if req.State.Raw.IsNull() {
+		if req.ConfigValue.IsNull() && req.PlanValue.IsUnknown() && m.client() != nil {
+			resp.PlanValue = types.StringValue(m.client().SiteID)
+		}
+		return
+	}
+	if req.ConfigValue.IsNull() {
+		resp.PlanValue = req.StateValue
+		return
+	}
+	if req.PlanValue.IsUnknown() || req.PlanValue.Equal(req.StateValue) {
+		return
+	}
+
+	same, err := sameSite(ctx, m.client(), req.StateValue.ValueString(), req.PlanValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Unknown site", err.Error())
+		return
+	}
+	resp.RequiresReplace = !same
+}
+
+// sameSite reports whether two site inputs name the same site. Without a
+// client there is no way to tell, so they are treated as different.
+func sameSite(ctx context.Context, client *unifi.Client, a, b string) (bool, error) {
+	if client == nil {
+		return false, nil
+	}
+	siteA, err := resolve(ctx, client, a)
+	if err != nil {
+		return false, err
+	}
+	siteB, err := resolve(ctx, client, b)
+	if err != nil {
+		return false, err
+	}
+	return siteA == siteB, nil
+}
+
+// resolve returns the ID of the site input names. The empty input and the
+// provider's site need no lookup.
+func resolve(ctx context.Context, client *unifi.Client, input string) (string, error) {
+	if input == "" || input == client.SiteID {
+		return client.SiteID, nil
+	}
+	site, err := client.ResolveSite(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("site %q: %w", input, err)
+	}
+	return site.ID, nil
+}
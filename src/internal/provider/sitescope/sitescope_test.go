@@ -0,0 +1,104 @@
+package sitescope
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+func TestContext_DefaultsToProviderSite(t *testing.T) {
+	client := unifi.NewClient("https://unifi.invalid", "key", "site-1", false)
+	site := types.StringNull()
+	var diags diag.Diagnostics
+
+	ctx := Context(context.Background(), client, &site, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if site.ValueString() != "site-1" {
+		t.Errorf("expected site to be set to the provider site, got %v", site)
+	}
+	if got := client.ContextSiteID(ctx); got != "site-1" {
+		t.Errorf("expected ctx on site-1, got %q", got)
+	}
+}
+
+func TestContext_LeavesUnknownSite(t *testing.T) {
+	client := unifi.NewClient("https://unifi.invalid", "key", "site-1", false)
+	site := types.StringUnknown()
+	var diags diag.Diagnostics
+
+	ctx := Context(context.Background(), client, &site, &diags)
+	if diags.HasError() || !site.IsUnknown() {
+		t.Errorf("expected site to stay unknown, got %v (%v)", site, diags)
+	}
+	if got := client.ContextSiteID(ctx); got != "site-1" {
+		t.Errorf("expected ctx on the provider site, got %q", got)
+	}
+}
+
+func TestDataSourceContext_LeavesSiteUnset(t *testing.T) {
+	client := unifi.NewClient("https://unifi.invalid", "key", "site-1", false)
+	var diags diag.Diagnostics
+
+	ctx := DataSourceContext(context.Background(), client, types.StringNull(), &diags)
+	if diags.HasError() || client.ContextSiteID(ctx) != "site-1" {
+		t.Errorf("expected the provider site, got %q (%v)", client.ContextSiteID(ctx), diags)
+	}
+}
+
+func modifySite(client *unifi.Client, state, config types.String) *planmodifier.StringResponse {
+	present := tftypes.NewValue(tftypes.String, "present")
+	prior := present
+	if state.IsNull() {
+		prior = tftypes.NewValue(tftypes.String, nil)
+	}
+	req := planmodifier.StringRequest{
+		State:       tfsdk.State{Raw: prior},
+		Plan:        tfsdk.Plan{Raw: present},
+		StateValue:  state,
+		ConfigValue: config,
+		PlanValue:   config,
+	}
+	if config.IsNull() {
+		req.PlanValue = types.StringUnknown()
+	}
+	resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+	replaceOnSiteChange{client: func() *unifi.Client { return client }}.PlanModifyString(context.Background(), req, resp)
+	return resp
+}
+
+func TestReplaceOnSiteChange(t *testing.T) {
+	client := unifi.NewClient("https://unifi.invalid", "key", "site-1", false)
+
+	resp := modifySite(client, types.StringValue("site-1"), types.StringNull())
+	if resp.RequiresReplace || resp.PlanValue.ValueString() != "site-1" {
+		t.Errorf("unset site: expected the prior site to be kept, got %v (replace %v)", resp.PlanValue, resp.RequiresReplace)
+	}
+
+	resp = modifySite(client, types.StringValue("site-1"), types.StringValue("site-1"))
+	if resp.RequiresReplace {
+		t.Error("unchanged site: expected no replacement")
+	}
+
+	resp = modifySite(nil, types.StringValue("site-1"), types.StringValue("Branch"))
+	if !resp.RequiresReplace {
+		t.Error("changed site without a client: expected a replacement")
+	}
+
+	resp = modifySite(client, types.StringNull(), types.StringNull())
+	if resp.PlanValue.ValueString() != "site-1" {
+		t.Errorf("new object: expected the provider site to be planned, got %v", resp.PlanValue)
+	}
+
+	resp = modifySite(client, types.StringNull(), types.StringUnknown())
+	if !resp.PlanValue.IsUnknown() {
+		t.Errorf("new object with an unknown site: expected it to stay unknown, got %v", resp.PlanValue)
+	}
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// ipv4AddressValidator checks that a string is a plain IPv4 address.
+type ipv4AddressValidator struct{}
+
+func (v ipv4AddressValidator) Description(ctx context.Context) string {
+	return "value must be an IPv4 address"
+}
+
+func (v ipv4AddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipv4AddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	addr, err := netip.ParseAddr(req.ConfigValue.ValueString())
+	if err != nil || !addr.Is4() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IPv4 address",
+			fmt.Sprintf("%q is not an IPv4 address.", req.ConfigValue.ValueString()))
+	}
+}
+
+// ipv4PrefixValidator checks that a string is an IPv4 network in CIDR
+// notation with no host bits set, e.g. 10.0.30.0/24.
+type ipv4PrefixValidator struct{}
+
+func (v ipv4PrefixValidator) Description(ctx context.Context) string {
+	return "value must be an IPv4 network in CIDR notation"
+}
+
+func (v ipv4PrefixValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipv4PrefixValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil || !prefix.Addr().Is4() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid subnet",
+			fmt.Sprintf("%q is not an IPv4 network in CIDR notation.", value))
+		return
+	}
+	if prefix.Bits() > 30 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid subnet",
+			fmt.Sprintf("%q leaves no room for hosts; use a /30 or larger.", value))
+		return
+	}
+	if masked := prefix.Masked(); masked != prefix {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid subnet",
+			fmt.Sprintf("%q has host bits set; did you mean %s? Set the gateway address with `gateway`.", value, masked))
+	}
+}
+
+// regexValidator checks that a string compiles as a regular expression.
+type regexValidator struct{}
+
+func (v regexValidator) Description(ctx context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid regular expression", err.Error())
+	}
+}
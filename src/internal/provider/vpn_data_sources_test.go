@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+func TestFindVPNServer(t *testing.T) {
+	servers := []unifi.VPNServer{
+		{ID: "vpn-1", Name: "Remote Access", Type: "WIREGUARD"},
+		{ID: "vpn-2", Name: "Legacy", Type: "L2TP"},
+		{ID: "vpn-3", Name: "Legacy", Type: "OPENVPN"},
+	}
+
+	if got, err := findVPNServer(servers, "", "Remote Access"); err != nil || got.ID != "vpn-1" {
+		t.Errorf("by name: got %v, %v", got, err)
+	}
+	if got, err := findVPNServer(servers, "vpn-3", ""); err != nil || got.Type != "OPENVPN" {
+		t.Errorf("by id: got %v, %v", got, err)
+	}
+	if _, err := findVPNServer(servers, "", "Legacy"); err == nil || !strings.Contains(err.Error(), "vpn-2") || !strings.Contains(err.Error(), "vpn-3") {
+		t.Errorf("expected ambiguity error listing both IDs, got %v", err)
+	}
+	if _, err := findVPNServer(servers, "", "Office"); err == nil || !strings.Contains(err.Error(), "name Office") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestFindSiteToSiteVPNTunnel(t *testing.T) {
+	tunnels := []unifi.SiteToSiteVPNTunnel{
+		{ID: "s2s-1", Name: "Branch Office", Type: "IPSEC"},
+		{ID: "s2s-2", Name: "Datacenter", Type: "WIREGUARD"},
+	}
+
+	if got, err := findSiteToSiteVPNTunnel(tunnels, "", "Datacenter"); err != nil || got.ID != "s2s-2" {
+		t.Errorf("by name: got %v, %v", got, err)
+	}
+	if got, err := findSiteToSiteVPNTunnel(tunnels, "s2s-1", ""); err != nil || got.Name != "Branch Office" {
+		t.Errorf("by id: got %v, %v", got, err)
+	}
+	if _, err := findSiteToSiteVPNTunnel(tunnels, "s2s-9", ""); err == nil || !strings.Contains(err.Error(), "ID s2s-9") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
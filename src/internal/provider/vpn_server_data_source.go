@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/provider/sitescope"
+	"github.com/someniak/terraform-provider-unifi-firewall/src/internal/unifi"
+)
+
+var (
+	_ datasource.DataSource                     = &VPNServerDataSource{}
+	_ datasource.DataSourceWithConfigure        = &VPNServerDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &VPNServerDataSource{}
+)
+
+// VPNServerDataSource looks up a remote-access VPN server, for use in a
+// unifi_fw vpn_server_filter.
+type VPNServerDataSource struct {
+	client *unifi.Client
+}
+
+type VPNServerDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Site    types.String `tfsdk:"site"`
+}
+
+func NewVPNServerDataSource() datasource.DataSource {
+	return &VPNServerDataSource{}
+}
+
+func (d *VPNServerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vpn_server"
+}
+
+func (d *VPNServerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a VPN server by name or ID.",
+		Attributes: map[string]schema.Attribute{
+			"site": sitescope.DataSourceAttribute(),
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the VPN server. Exactly one of `name` or `id` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The name of the VPN server. Exactly one of `name` or `id` must be set.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The VPN protocol, such as `WIREGUARD`, `OPENVPN` or `L2TP`.",
+			},
+			"enabled": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the VPN server is enabled.",
+			},
+		},
+	}
+}
+
+func (d *VPNServerDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("id")),
+	}
+}
+
+func (d *VPNServerDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*unifi.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *unifi.Client, got %T", req.ProviderData))
+		return
+	}
+
+	d.client = client
+}
+
+func (d *VPNServerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VPNServerDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sitescope.DataSourceContext(ctx, d.client, data.Site, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	servers, err := d.client.ListVPNServers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing VPN servers", err.Error())
+		return
+	}
+
+	server, err := findVPNServer(servers, data.ID.ValueString(), data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("VPN server not found", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(server.ID)
+	data.Name = types.StringValue(server.Name)
+	data.Type = types.StringValue(server.Type)
+	data.Enabled = types.BoolValue(server.Enabled)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findVPNServer returns the server with the given ID, or else the single
+// server with the given name.
+func findVPNServer(servers []unifi.VPNServer, id, name string) (*unifi.VPNServer, error) {
+	var found *unifi.VPNServer
+	for i := range servers {
+		if id != "" {
+			if servers[i].ID == id {
+				return &servers[i], nil
+			}
+			continue
+		}
+		if servers[i].Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("more than one VPN server named %s (IDs %s and %s)", name, found.ID, servers[i].ID)
+		}
+		found = &servers[i]
+	}
+	if found != nil {
+		return found, nil
+	}
+	if id != "" {
+		return nil, fmt.Errorf("VPN server with ID %s not found", id)
+	}
+	return nil, fmt.Errorf("VPN server with name %s not found", name)
+}
@@ -0,0 +1,136 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// errLoginEndpointNotFound signals that a login endpoint does not exist on
+// this controller, so the next candidate should be tried.
+var errLoginEndpointNotFound = errors.New("login endpoint not found")
+
+// loginURLs returns the login endpoints to try, most likely first. UniFi OS
+// consoles (base URL under /proxy/network) authenticate at the console root
+// via /api/auth/login; classic Network Applications use /api/login. Once a
+// login succeeded, only that endpoint is used.
+func (c *Client) loginURLs() []string {
+	if c.loginURL != "" {
+		return []string{c.loginURL}
+	}
+
+	classic := c.networkBaseURL() + "/api/login"
+	u, err := url.Parse(c.BaseURL)
+	if err != nil || u.Host == "" {
+		return []string{classic}
+	}
+	unifiOS := fmt.Sprintf("%s://%s/api/auth/login", u.Scheme, u.Host)
+
+	if strings.Contains(u.Path, "/proxy/network") {
+		return []string{unifiOS, classic}
+	}
+	return []string{classic, unifiOS}
+}
+
+// login authenticates with the stored credentials and records the session's
+// CSRF token. The caller must hold c.authMu.
+func (c *Client) login(ctx context.Context) error {
+	var lastErr error
+	for _, loginURL := range c.loginURLs() {
+		err := c.loginAt(ctx, loginURL)
+		if err == nil {
+			c.loginURL = loginURL
+			c.sessionGen++
+			return nil
+		}
+		if !errors.Is(err, errLoginEndpointNotFound) {
+			return err
+		}
+		lastErr = err
+	}
+	return lastErr
+}
+
+func (c *Client) loginAt(ctx context.Context, loginURL string) error {
+	payload, _ := json.Marshal(map[string]string{
+		"username": c.username,
+		"password": c.password,
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, loginURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", errLoginEndpointNotFound, loginURL)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("login returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	// Extract CSRF token from response header or cookie
+	csrfToken := resp.Header.Get("X-CSRF-Token")
+	if csrfToken == "" {
+		for _, cookie := range resp.Cookies() {
+			if cookie.Name == "csrf_token" {
+				csrfToken = cookie.Value
+				break
+			}
+		}
+	}
+	c.csrfToken = csrfToken
+
+	return nil
+}
+
+// relogin renews an expired session. sessionGen is the generation the failed
+// request was sent with; if another request has logged in since, the session
+// is already fresh and the caller only needs to replay its request.
+func (c *Client) relogin(ctx context.Context, sessionGen int) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if c.sessionGen != sessionGen {
+		return nil
+	}
+	return c.login(ctx)
+}
+
+// updateCSRFToken picks up a rotated CSRF token from any response. UniFi OS
+// announces rotations via X-Updated-CSRF-Token.
+func (c *Client) updateCSRFToken(header http.Header) {
+	token := header.Get("X-Updated-CSRF-Token")
+	if token == "" {
+		token = header.Get("X-CSRF-Token")
+	}
+	if token == "" {
+		return
+	}
+
+	c.authMu.Lock()
+	c.csrfToken = token
+	c.authMu.Unlock()
+}
+
+// isSessionError reports whether err means the session cookie or CSRF token
+// is no longer accepted.
+func isSessionError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
@@ -2,8 +2,10 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -32,10 +34,35 @@ func (e *cacheEntry[T]) valid() bool {
 	return time.Now().Before(e.expiresAt)
 }
 
-// cacheTTL controls how long list responses are cached. Within a single
-// terraform plan/apply cycle this avoids redundant list calls when multiple
-// data sources or resource reads need the same data.
-const cacheTTL = 2 * time.Minute
+// siteCache holds one cached list response per site ID, so that lists of
+// different sites never stand in for each other.
+type siteCache[T any] map[string]*cacheEntry[T]
+
+// get returns the cached list of site, if there is one that has not expired.
+func (s siteCache[T]) get(site string) (T, bool) {
+	if e := s[site]; e != nil && e.valid() {
+		return e.data, true
+	}
+	var zero T
+	return zero, false
+}
+
+func (s *siteCache[T]) put(site string, data T, ttl time.Duration) {
+	if *s == nil {
+		*s = siteCache[T]{}
+	}
+	(*s)[site] = &cacheEntry[T]{data: data, expiresAt: time.Now().Add(ttl)}
+}
+
+// sharedKey returns the singleflight key of a list call of endpoint in site.
+func sharedKey(endpoint, site string) string {
+	return endpoint + "/" + site
+}
+
+// DefaultCacheTTL controls how long list responses are cached by default.
+// Within a single terraform plan/apply cycle this avoids redundant list calls
+// when multiple data sources or resource reads need the same data.
+const DefaultCacheTTL = 2 * time.Minute
 
 type Client struct {
 	BaseURL       string
@@ -44,16 +71,30 @@ type Client struct {
 	SiteReference string // e.g. "default" — used for legacy REST API paths
 	Insecure      bool
 	HTTPClient    *http.Client
-
-	authMode  authMode
-	csrfToken string
-
-	mu             sync.Mutex
-	sf             singleflight.Group
-	zoneCache      *cacheEntry[[]FirewallZone]
-	networkCache   *cacheEntry[[]Network]
-	fwPolicyCache  *cacheEntry[[]FirewallPolicy]
-	dnsPolicyCache *cacheEntry[[]DNSPolicy]
+	Retry         RetryPolicy
+	CacheTTL      time.Duration // how long list responses are cached; 0 disables caching
+
+	authMode authMode
+	username string
+	password string
+
+	authMu     sync.Mutex // serializes logins and guards the session fields below
+	csrfToken  string
+	sessionGen int    // incremented on every successful login
+	loginURL   string // login endpoint that accepted the last login
+
+	mu               sync.Mutex
+	sf               singleflight.Group
+	zoneCache        siteCache[[]FirewallZone]
+	networkCache     siteCache[[]Network]
+	fwPolicyCache    siteCache[[]FirewallPolicy]
+	dnsPolicyCache   siteCache[[]DNSPolicy]
+	trafficListCache siteCache[[]TrafficMatchingList]
+	dpiAppCache      *cacheEntry[[]DPIApplication] // DPI data is not site-specific
+	dpiCategoryCache *cacheEntry[[]DPICategory]
+	vpnServerCache   siteCache[[]VPNServer]
+	vpnTunnelCache   siteCache[[]SiteToSiteVPNTunnel]
+	sites            map[string]Site // resolved site inputs, see ResolveSite
 }
 
 func NewClient(baseUrl, apiKey, siteId string, insecure bool) *Client {
@@ -69,13 +110,16 @@ func NewClient(baseUrl, apiKey, siteId string, insecure bool) *Client {
 			Timeout:   time.Minute,
 			Transport: tr,
 		},
+		Retry:    DefaultRetryPolicy(),
+		CacheTTL: DefaultCacheTTL,
 	}
 }
 
-// NewClientWithCredentials creates a client that authenticates via legacy
-// cookie-based login (POST /api/login). This is used for self-hosted UniFi
-// Network Application instances that don't support API keys.
-func NewClientWithCredentials(baseURL, username, password, siteID string, insecure bool) (*Client, error) {
+// NewClientWithCredentials creates a client that authenticates via cookie-based
+// login, either on UniFi OS (POST /api/auth/login) or on a classic Network
+// Application (POST /api/login). This is used for self-hosted instances that
+// don't support API keys. Expired sessions are renewed transparently.
+func NewClientWithCredentials(ctx context.Context, baseURL, username, password, siteID string, insecure bool) (*Client, error) {
 	jar, err := cookiejar.New(nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
@@ -90,14 +134,21 @@ func NewClientWithCredentials(baseURL, username, password, siteID string, insecu
 		SiteID:   siteID,
 		Insecure: insecure,
 		authMode: authModeCookie,
+		username: username,
+		password: password,
 		HTTPClient: &http.Client{
 			Timeout:   time.Minute,
 			Transport: tr,
 			Jar:       jar,
 		},
+		Retry:    DefaultRetryPolicy(),
+		CacheTTL: DefaultCacheTTL,
 	}
 
-	if err := c.login(username, password); err != nil {
+	c.authMu.Lock()
+	err = c.login(ctx)
+	c.authMu.Unlock()
+	if err != nil {
 		return nil, fmt.Errorf("login failed: %w", err)
 	}
 
@@ -111,46 +162,7 @@ func (c *Client) networkBaseURL() string {
 	return strings.TrimSuffix(c.BaseURL, "/integration")
 }
 
-func (c *Client) login(username, password string) error {
-	loginURL := fmt.Sprintf("%s/api/login", c.networkBaseURL())
-	payload, _ := json.Marshal(map[string]string{
-		"username": username,
-		"password": password,
-	})
-
-	req, err := http.NewRequest(http.MethodPost, loginURL, bytes.NewBuffer(payload))
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("login returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Extract CSRF token from response header or cookie
-	csrfToken := resp.Header.Get("X-CSRF-Token")
-	if csrfToken == "" {
-		for _, cookie := range resp.Cookies() {
-			if cookie.Name == "csrf_token" {
-				csrfToken = cookie.Value
-				break
-			}
-		}
-	}
-	c.csrfToken = csrfToken
-
-	return nil
-}
-
-// InvalidateCache clears all cached data. Call after any mutation
+// InvalidateCache clears all cached data of every site. Call after any mutation
 // (create/update/delete) to ensure subsequent reads see fresh data.
 func (c *Client) InvalidateCache() {
 	c.mu.Lock()
@@ -159,53 +171,157 @@ func (c *Client) InvalidateCache() {
 	c.networkCache = nil
 	c.fwPolicyCache = nil
 	c.dnsPolicyCache = nil
+	c.trafficListCache = nil
+	c.dpiAppCache = nil
+	c.dpiCategoryCache = nil
+	c.vpnServerCache = nil
+	c.vpnTunnelCache = nil
 }
 
-// invalidateFWPolicyCache clears just the firewall policy cache.
-func (c *Client) invalidateFWPolicyCache() {
+// invalidateFWPolicyCache clears just the firewall policy cache of site.
+func (c *Client) invalidateFWPolicyCache(site string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.fwPolicyCache = nil
+	delete(c.fwPolicyCache, site)
 }
 
-// invalidateDNSPolicyCache clears the DNS policy cache.
-func (c *Client) invalidateDNSPolicyCache() {
+// invalidateNetworkCache clears the network cache of site, and its zone cache
+// too since zones list their member networks.
+func (c *Client) invalidateNetworkCache(site string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.dnsPolicyCache = nil
+	delete(c.networkCache, site)
+	delete(c.zoneCache, site)
+}
+
+// invalidateZoneCache clears the firewall zone cache of site.
+func (c *Client) invalidateZoneCache(site string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.zoneCache, site)
+}
+
+// invalidateDNSPolicyCache clears the DNS policy cache of site.
+func (c *Client) invalidateDNSPolicyCache(site string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.dnsPolicyCache, site)
+}
+
+// invalidateTrafficListCache clears the traffic matching list cache of site.
+func (c *Client) invalidateTrafficListCache(site string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.trafficListCache, site)
 }
 
+// doShared runs fn through the singleflight group so concurrent callers share
+// one in-flight list call. Each caller stops waiting as soon as its own ctx is
+// done. If the shared call was cancelled by another caller's context while
+// ctx is still live, it is started once more on behalf of this caller.
+func (c *Client) doShared(ctx context.Context, key string, fn func() (interface{}, error)) (interface{}, error) {
+	for attempt := 0; ; attempt++ {
+		select {
+		case res := <-c.sf.DoChan(key, fn):
+			if attempt == 0 && errors.Is(res.Err, context.Canceled) && ctx.Err() == nil {
+				continue
+			}
+			return res.Val, res.Err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// doRequest sends req, retrying transient failures according to c.Retry. With
+// cookie authentication, a 401/403 triggers a single re-login and replay of the
+// request, which does not count against the retry budget.
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	reauthenticated := false
+	for attempt := 0; ; {
+		body, sessionGen, err := c.doRequestOnce(req)
+		if err == nil {
+			return body, nil
+		}
+
+		if c.authMode == authModeCookie && !reauthenticated && isSessionError(err) {
+			reauthenticated = true
+			if loginErr := c.relogin(req.Context(), sessionGen); loginErr != nil {
+				return nil, fmt.Errorf("%w (re-login failed: %v)", err, loginErr)
+			}
+		} else {
+			if attempt >= c.Retry.MaxRetries || req.Context().Err() != nil || !shouldRetry(req.Method, err) {
+				return nil, err
+			}
+
+			var retryAfter time.Duration
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				retryAfter = apiErr.retryAfter
+			}
+			if err := sleepContext(req.Context(), c.Retry.backoff(attempt, retryAfter)); err != nil {
+				return nil, err
+			}
+			attempt++
+		}
+
+		// The previous attempt consumed the body; rewind it for the replay.
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// doRequestOnce sends req a single time. It also returns the session
+// generation the request was authenticated with, so a failed request can tell
+// whether another goroutine has already renewed the session.
+func (c *Client) doRequestOnce(req *http.Request) ([]byte, int, error) {
 	req.Header.Set("Accept", "application/json")
 	if req.Body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	var sessionGen int
 	switch c.authMode {
 	case authModeAPIKey:
 		req.Header.Set("X-API-Key", c.APIKey)
 	case authModeCookie:
-		if c.csrfToken != "" {
-			req.Header.Set("X-CSRF-Token", c.csrfToken)
+		// http.Client adds jar cookies to req itself; drop those from a
+		// previous attempt so a replay carries only the current session.
+		req.Header.Del("Cookie")
+		c.authMu.Lock()
+		csrfToken := c.csrfToken
+		sessionGen = c.sessionGen
+		c.authMu.Unlock()
+		if csrfToken != "" {
+			req.Header.Set("X-CSRF-Token", csrfToken)
 		}
 	}
 
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, sessionGen, err
 	}
 	defer res.Body.Close()
 
+	if c.authMode == authModeCookie {
+		c.updateCSRFToken(res.Header)
+	}
+
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return nil, sessionGen, err
 	}
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return nil, fmt.Errorf("api error: status %d, body: %s", res.StatusCode, string(body))
+		apiErr := newAPIError(res.StatusCode, body)
+		apiErr.retryAfter = parseRetryAfter(res.Header.Get("Retry-After"))
+		return nil, sessionGen, apiErr
 	}
 
-	return body, nil
+	return body, sessionGen, nil
 }
 
 // Sites
@@ -215,49 +331,99 @@ type Site struct {
 	InternalReference string `json:"internalReference"`
 }
 
-func (c *Client) ListSites() ([]Site, error) {
-	url := fmt.Sprintf("%s/v1/sites?limit=200", c.BaseURL)
-	req, _ := http.NewRequest(http.MethodGet, url, nil)
+func (c *Client) ListSites(ctx context.Context) ([]Site, error) {
+	var allSites []Site
+	offset := 0
+	const pageSize = 200
 
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
+	for {
+		url := fmt.Sprintf("%s/v1/sites?limit=%d&offset=%d", c.BaseURL, pageSize, offset)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
-	var response struct {
-		Data []Site `json:"data"`
-	}
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal sites: %w. response body: %s", err, string(body))
+		body, err := c.doRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Data []Site `json:"data"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal sites: %w. response body: %s", err, string(body))
+		}
+
+		allSites = append(allSites, response.Data...)
+		if len(response.Data) < pageSize {
+			break
+		}
+		offset += pageSize
 	}
 
-	return response.Data, nil
+	return allSites, nil
 }
 
 // Firewall Zones
 type FirewallZone struct {
-	ID         string   `json:"id"`
-	Name       string   `json:"name"`
-	NetworkIDs []string `json:"networkIds"`
+	ID         string                `json:"id,omitempty"`
+	Name       string                `json:"name"`
+	NetworkIDs []string              `json:"networkIds"`
+	Metadata   *FirewallZoneMetadata `json:"metadata,omitempty"`
+}
+
+type FirewallZoneMetadata struct {
+	Origin string `json:"origin,omitempty"` // SYSTEM_DEFINED or USER_DEFINED
+}
+
+// PredefinedZoneNames are the zones every UniFi gateway ships with. They
+// cannot be created, renamed or deleted.
+var PredefinedZoneNames = []string{"Internal", "External", "Gateway", "VPN", "Hotspot", "DMZ"}
+
+// IsPredefinedZoneName reports whether name belongs to a built-in zone. The
+// comparison ignores case since controller versions differ ("Vpn" vs "VPN").
+func IsPredefinedZoneName(name string) bool {
+	for _, predefined := range PredefinedZoneNames {
+		if strings.EqualFold(name, predefined) {
+			return true
+		}
+	}
+	return false
+}
+
+// Predefined reports whether z is a built-in zone. Controllers that do not
+// send metadata are matched by name.
+func (z FirewallZone) Predefined() bool {
+	return z.Origin() == "SYSTEM_DEFINED"
+}
+
+// Origin returns SYSTEM_DEFINED or USER_DEFINED, falling back to the zone
+// name when the controller does not send metadata.
+func (z FirewallZone) Origin() string {
+	if z.Metadata != nil && z.Metadata.Origin != "" {
+		return z.Metadata.Origin
+	}
+	if IsPredefinedZoneName(z.Name) {
+		return "SYSTEM_DEFINED"
+	}
+	return "USER_DEFINED"
 }
 
-func (c *Client) ListFirewallZones() ([]FirewallZone, error) {
+func (c *Client) ListFirewallZones(ctx context.Context) ([]FirewallZone, error) {
+	site := c.siteID(ctx)
 	c.mu.Lock()
-	if c.zoneCache != nil && c.zoneCache.valid() {
-		zones := c.zoneCache.data
+	if zones, ok := c.zoneCache.get(site); ok {
 		c.mu.Unlock()
 		return zones, nil
 	}
 	c.mu.Unlock()
 
-	v, err, _ := c.sf.Do("fw-zones", func() (interface{}, error) {
+	v, err := c.doShared(ctx, sharedKey("fw-zones", site), func() (interface{}, error) {
 		var allZones []FirewallZone
 		offset := 0
 		const pageSize = 200
 
 		for {
-			url := fmt.Sprintf("%s/v1/sites/%s/firewall/zones?limit=%d&offset=%d", c.BaseURL, c.SiteID, pageSize, offset)
-			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			url := fmt.Sprintf("%s/v1/sites/%s/firewall/zones?limit=%d&offset=%d", c.BaseURL, site, pageSize, offset)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
 			body, err := c.doRequest(req)
 			if err != nil {
@@ -279,7 +445,7 @@ func (c *Client) ListFirewallZones() ([]FirewallZone, error) {
 		}
 
 		c.mu.Lock()
-		c.zoneCache = &cacheEntry[[]FirewallZone]{data: allZones, expiresAt: time.Now().Add(cacheTTL)}
+		c.zoneCache.put(site, allZones, c.CacheTTL)
 		c.mu.Unlock()
 
 		return allZones, nil
@@ -290,6 +456,87 @@ func (c *Client) ListFirewallZones() ([]FirewallZone, error) {
 	return v.([]FirewallZone), nil
 }
 
+func (c *Client) CreateFirewallZone(ctx context.Context, zone FirewallZone) (*FirewallZone, error) {
+	url := fmt.Sprintf("%s/v1/sites/%s/firewall/zones", c.BaseURL, c.siteID(ctx))
+	payload, _ := json.Marshal(zone)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload))
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result FirewallZone
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	// Member networks move to the new zone, which changes their zoneId.
+	if len(zone.NetworkIDs) > 0 {
+		c.invalidateNetworkCache(c.siteID(ctx))
+	} else {
+		c.invalidateZoneCache(c.siteID(ctx))
+	}
+	return &result, nil
+}
+
+// GetFirewallZone retrieves a single zone, preferring the cached zone list and
+// falling back to a direct GET.
+func (c *Client) GetFirewallZone(ctx context.Context, zoneID string) (*FirewallZone, error) {
+	zones, err := c.ListFirewallZones(ctx)
+	if err == nil {
+		for i := range zones {
+			if zones[i].ID == zoneID {
+				return &zones[i], nil
+			}
+		}
+	}
+
+	url := fmt.Sprintf("%s/v1/sites/%s/firewall/zones/%s", c.BaseURL, c.siteID(ctx), zoneID)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result FirewallZone
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) UpdateFirewallZone(ctx context.Context, zoneID string, zone FirewallZone) (*FirewallZone, error) {
+	url := fmt.Sprintf("%s/v1/sites/%s/firewall/zones/%s", c.BaseURL, c.siteID(ctx), zoneID)
+	payload, _ := json.Marshal(zone)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewBuffer(payload))
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result FirewallZone
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	// Networks added to or removed from the zone change their zoneId.
+	c.invalidateNetworkCache(c.siteID(ctx))
+	return &result, nil
+}
+
+func (c *Client) DeleteFirewallZone(ctx context.Context, zoneID string) error {
+	url := fmt.Sprintf("%s/v1/sites/%s/firewall/zones/%s", c.BaseURL, c.siteID(ctx), zoneID)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	_, err := c.doRequest(req)
+	// The zone's networks fall back to their default zone.
+	c.invalidateNetworkCache(c.siteID(ctx))
+	return err
+}
+
 // Firewall Policies
 type FirewallPolicy struct {
 	ID                    string             `json:"id,omitempty"`
@@ -317,27 +564,67 @@ type FirewallSourceDest struct {
 }
 
 type TrafficFilter struct {
-	Type             string           `json:"type"` // PORT, NETWORK, MAC_ADDRESS, IP_ADDRESS, IPV6_IID, REGION, VPN_SERVER, SITE_TO_SITE_VPN_TUNNEL, DOMAIN (dest only), APPLICATION (dest only)
-	PortFilter       *PortFilter      `json:"portFilter,omitempty"`
-	DomainFilter     *DomainFilter    `json:"domainFilter,omitempty"`
-	IPAddressFilter  *IPAddressFilter `json:"ipAddressFilter,omitempty"`
-	NetworkFilter    *NetworkFilter   `json:"networkFilter,omitempty"`
-	MACAddressFilter interface{}      `json:"macAddressFilter,omitempty"` // Polymorphic: string (additional) or *MACAddressFilter (standalone)
+	Type                      string                     `json:"type"` // PORT, NETWORK, MAC_ADDRESS, IP_ADDRESS, IPV6_IID, REGION, VPN_SERVER, SITE_TO_SITE_VPN_TUNNEL, DOMAIN (dest only), APPLICATION (dest only)
+	PortFilter                *PortFilter                `json:"portFilter,omitempty"`
+	DomainFilter              *DomainFilter              `json:"domainFilter,omitempty"`
+	IPAddressFilter           *IPAddressFilter           `json:"ipAddressFilter,omitempty"`
+	NetworkFilter             *NetworkFilter             `json:"networkFilter,omitempty"`
+	MACAddressFilter          *MACAddressFilter          `json:"macAddressFilter,omitempty"`
+	ApplicationFilter         *ApplicationFilter         `json:"applicationFilter,omitempty"`
+	RegionFilter              *RegionFilter              `json:"regionFilter,omitempty"`
+	VPNServerFilter           *VPNServerFilter           `json:"vpnServerFilter,omitempty"`
+	SiteToSiteVPNTunnelFilter *SiteToSiteVPNTunnelFilter `json:"siteToSiteVpnTunnelFilter,omitempty"`
+	IPv6IIDFilter             *IPv6IIDFilter             `json:"ipv6IidFilter,omitempty"`
 }
 
 type IPAddressFilter struct {
-	Type          string          `json:"type"` // IP_ADDRESSES, TRAFFIC_MATCHING_LIST
-	MatchOpposite bool            `json:"matchOpposite"`
-	Items         []IPAddressItem `json:"items"`
+	Type                  string          `json:"type"` // IP_ADDRESSES, TRAFFIC_MATCHING_LIST
+	MatchOpposite         bool            `json:"matchOpposite"`
+	Items                 []IPAddressItem `json:"items,omitempty"`
+	TrafficMatchingListID string          `json:"trafficMatchingListId,omitempty"` // TRAFFIC_MATCHING_LIST only
 }
 
 type IPAddressItem struct {
-	Type  string `json:"type"` // IP_ADDRESS, SUBNET, IP_ADDRESS_RANGE
-	Value string `json:"value"`
+	Type  string `json:"type"`            // IP_ADDRESS, SUBNET, IP_ADDRESS_RANGE
+	Value string `json:"value,omitempty"` // IP_ADDRESS and SUBNET
+	Start string `json:"start,omitempty"` // IP_ADDRESS_RANGE only
+	Stop  string `json:"stop,omitempty"`  // IP_ADDRESS_RANGE only
 }
 
+// MACAddressFilter is polymorphic on the wire. As an addition to another
+// filter type it is a bare MAC address string; as a MAC_ADDRESS filter it is
+// an object. Address is set for the first form and the remaining fields for
+// the second.
 type MACAddressFilter struct {
-	MACAddresses []string `json:"macAddresses"`
+	Address string `json:"-"`
+
+	Type          string   `json:"type,omitempty"` // MAC_ADDRESSES
+	MatchOpposite bool     `json:"matchOpposite"`
+	MACAddresses  []string `json:"macAddresses"`
+}
+
+// macAddressFilterObject has the fields of MACAddressFilter without its
+// methods, so the object form can use the default encoding.
+type macAddressFilterObject MACAddressFilter
+
+func (f MACAddressFilter) MarshalJSON() ([]byte, error) {
+	if f.Address != "" {
+		return json.Marshal(f.Address)
+	}
+	return json.Marshal(macAddressFilterObject(f))
+}
+
+func (f *MACAddressFilter) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*f = MACAddressFilter{}
+		return json.Unmarshal(data, &f.Address)
+	}
+	var obj macAddressFilterObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("macAddressFilter: %w", err)
+	}
+	*f = MACAddressFilter(obj)
+	return nil
 }
 
 type NetworkFilter struct {
@@ -345,15 +632,42 @@ type NetworkFilter struct {
 	NetworkIDs    []string `json:"networkIds"`
 }
 
+type IPv6IIDFilter struct {
+	MatchOpposite bool     `json:"matchOpposite"`
+	Items         []string `json:"items"` // interface identifiers, e.g. "::1a2b:3c4d:5e6f:7a8b"
+}
+
+type VPNServerFilter struct {
+	MatchOpposite bool     `json:"matchOpposite"`
+	VPNServerIDs  []string `json:"vpnServerIds"`
+}
+
+type SiteToSiteVPNTunnelFilter struct {
+	MatchOpposite bool     `json:"matchOpposite"`
+	TunnelIDs     []string `json:"siteToSiteVpnTunnelIds"`
+}
+
+type RegionFilter struct {
+	MatchOpposite bool     `json:"matchOpposite"`
+	Regions       []string `json:"regions"` // ISO 3166-1 alpha-2 country codes
+}
+
+type ApplicationFilter struct {
+	Type                   string `json:"type"` // APPLICATIONS, APPLICATION_CATEGORIES
+	ApplicationIDs         []int  `json:"applicationIds,omitempty"`
+	ApplicationCategoryIDs []int  `json:"applicationCategoryIds,omitempty"`
+}
+
 type DomainFilter struct {
 	Type    string   `json:"type"` // DOMAINS
 	Domains []string `json:"domains"`
 }
 
 type PortFilter struct {
-	Type          string     `json:"type"` // PORTS, TRAFFIC_MATCHING_LIST
-	MatchOpposite bool       `json:"matchOpposite"`
-	Items         []PortItem `json:"items"`
+	Type                  string     `json:"type"` // PORTS, TRAFFIC_MATCHING_LIST
+	MatchOpposite         bool       `json:"matchOpposite"`
+	Items                 []PortItem `json:"items,omitempty"`
+	TrafficMatchingListID string     `json:"trafficMatchingListId,omitempty"` // TRAFFIC_MATCHING_LIST only
 }
 
 type PortItem struct {
@@ -375,31 +689,40 @@ type ProtocolFilter struct {
 }
 
 type FirewallSchedule struct {
-	Mode         string   `json:"mode"`                   // EVERY_DAY, EVERY_WEEK, ONE_TIME_ONLY
-	RepeatOnDays []string `json:"repeatOnDays,omitempty"`  // e.g. ["MONDAY","TUESDAY"] — EVERY_WEEK only
-	Start        string   `json:"start,omitempty"`         // ONE_TIME_ONLY start datetime
-	Stop         string   `json:"stop,omitempty"`          // ONE_TIME_ONLY stop datetime
+	Mode         string              `json:"mode"`                   // EVERY_DAY, EVERY_WEEK, ONE_TIME_ONLY
+	RepeatOnDays []string            `json:"repeatOnDays,omitempty"` // e.g. ["MONDAY","TUESDAY"] — EVERY_WEEK only
+	Start        string              `json:"start,omitempty"`        // ONE_TIME_ONLY start datetime
+	Stop         string              `json:"stop,omitempty"`         // ONE_TIME_ONLY stop datetime
+	TimeFilter   *ScheduleTimeFilter `json:"timeFilter,omitempty"`   // time of day for EVERY_DAY, EVERY_WEEK and CUSTOM
+}
+
+// ScheduleTimeFilter restricts a recurring schedule to a time of day. A stop
+// time earlier than the start time spans midnight (e.g. 22:00 to 07:00).
+type ScheduleTimeFilter struct {
+	Type      string `json:"type"`                // ALL_DAY, TIME_RANGE
+	StartTime string `json:"startTime,omitempty"` // HH:MM, TIME_RANGE only
+	StopTime  string `json:"stopTime,omitempty"`  // HH:MM, TIME_RANGE only
 }
 
 // ListFirewallPolicies fetches all firewall policies, using a short-lived cache
 // so that multiple resource reads within the same plan/apply share one API call.
-func (c *Client) ListFirewallPolicies() ([]FirewallPolicy, error) {
+func (c *Client) ListFirewallPolicies(ctx context.Context) ([]FirewallPolicy, error) {
+	site := c.siteID(ctx)
 	c.mu.Lock()
-	if c.fwPolicyCache != nil && c.fwPolicyCache.valid() {
-		policies := c.fwPolicyCache.data
+	if policies, ok := c.fwPolicyCache.get(site); ok {
 		c.mu.Unlock()
 		return policies, nil
 	}
 	c.mu.Unlock()
 
-	v, err, _ := c.sf.Do("fw-policies", func() (interface{}, error) {
+	v, err := c.doShared(ctx, sharedKey("fw-policies", site), func() (interface{}, error) {
 		var allPolicies []FirewallPolicy
 		offset := 0
 		const pageSize = 200
 
 		for {
-			url := fmt.Sprintf("%s/v1/sites/%s/firewall/policies?limit=%d&offset=%d", c.BaseURL, c.SiteID, pageSize, offset)
-			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			url := fmt.Sprintf("%s/v1/sites/%s/firewall/policies?limit=%d&offset=%d", c.BaseURL, site, pageSize, offset)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
 			body, err := c.doRequest(req)
 			if err != nil {
@@ -421,7 +744,7 @@ func (c *Client) ListFirewallPolicies() ([]FirewallPolicy, error) {
 		}
 
 		c.mu.Lock()
-		c.fwPolicyCache = &cacheEntry[[]FirewallPolicy]{data: allPolicies, expiresAt: time.Now().Add(cacheTTL)}
+		c.fwPolicyCache.put(site, allPolicies, c.CacheTTL)
 		c.mu.Unlock()
 
 		return allPolicies, nil
@@ -432,10 +755,10 @@ func (c *Client) ListFirewallPolicies() ([]FirewallPolicy, error) {
 	return v.([]FirewallPolicy), nil
 }
 
-func (c *Client) CreateFirewallPolicy(policy FirewallPolicy) (*FirewallPolicy, error) {
-	url := fmt.Sprintf("%s/v1/sites/%s/firewall/policies", c.BaseURL, c.SiteID)
+func (c *Client) CreateFirewallPolicy(ctx context.Context, policy FirewallPolicy) (*FirewallPolicy, error) {
+	url := fmt.Sprintf("%s/v1/sites/%s/firewall/policies", c.BaseURL, c.siteID(ctx))
 	payload, _ := json.Marshal(policy)
-	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(payload))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload))
 
 	body, err := c.doRequest(req)
 	if err != nil {
@@ -447,15 +770,15 @@ func (c *Client) CreateFirewallPolicy(policy FirewallPolicy) (*FirewallPolicy, e
 		return nil, err
 	}
 
-	c.invalidateFWPolicyCache()
+	c.invalidateFWPolicyCache(c.siteID(ctx))
 	return &result, nil
 }
 
 // GetFirewallPolicy retrieves a single policy. It first checks the cached list
 // of all policies (populated by ListFirewallPolicies) to avoid an extra API call.
 // Falls back to a direct GET if the policy is not in cache.
-func (c *Client) GetFirewallPolicy(policyId string) (*FirewallPolicy, error) {
-	policies, err := c.ListFirewallPolicies()
+func (c *Client) GetFirewallPolicy(ctx context.Context, policyId string) (*FirewallPolicy, error) {
+	policies, err := c.ListFirewallPolicies(ctx)
 	if err == nil {
 		for i := range policies {
 			if policies[i].ID == policyId {
@@ -465,8 +788,8 @@ func (c *Client) GetFirewallPolicy(policyId string) (*FirewallPolicy, error) {
 	}
 
 	// Fallback: direct GET for a single policy (e.g. newly created, not yet in cache).
-	url := fmt.Sprintf("%s/v1/sites/%s/firewall/policies/%s", c.BaseURL, c.SiteID, policyId)
-	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	url := fmt.Sprintf("%s/v1/sites/%s/firewall/policies/%s", c.BaseURL, c.siteID(ctx), policyId)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
 	body, err := c.doRequest(req)
 	if err != nil {
@@ -481,10 +804,10 @@ func (c *Client) GetFirewallPolicy(policyId string) (*FirewallPolicy, error) {
 	return &result, nil
 }
 
-func (c *Client) UpdateFirewallPolicy(policyId string, policy FirewallPolicy) (*FirewallPolicy, error) {
-	url := fmt.Sprintf("%s/v1/sites/%s/firewall/policies/%s", c.BaseURL, c.SiteID, policyId)
+func (c *Client) UpdateFirewallPolicy(ctx context.Context, policyId string, policy FirewallPolicy) (*FirewallPolicy, error) {
+	url := fmt.Sprintf("%s/v1/sites/%s/firewall/policies/%s", c.BaseURL, c.siteID(ctx), policyId)
 	payload, _ := json.Marshal(policy)
-	req, _ := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(payload))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewBuffer(payload))
 
 	body, err := c.doRequest(req)
 	if err != nil {
@@ -496,43 +819,260 @@ func (c *Client) UpdateFirewallPolicy(policyId string, policy FirewallPolicy) (*
 		return nil, err
 	}
 
-	c.invalidateFWPolicyCache()
+	c.invalidateFWPolicyCache(c.siteID(ctx))
 	return &result, nil
 }
 
-func (c *Client) DeleteFirewallPolicy(policyId string) error {
-	url := fmt.Sprintf("%s/v1/sites/%s/firewall/policies/%s", c.BaseURL, c.SiteID, policyId)
-	req, _ := http.NewRequest(http.MethodDelete, url, nil)
+func (c *Client) DeleteFirewallPolicy(ctx context.Context, policyId string) error {
+	url := fmt.Sprintf("%s/v1/sites/%s/firewall/policies/%s", c.BaseURL, c.siteID(ctx), policyId)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	_, err := c.doRequest(req)
-	c.invalidateFWPolicyCache()
+	c.invalidateFWPolicyCache(c.siteID(ctx))
+	return err
+}
+
+// FirewallPolicyOrdering is the evaluation order of the user-defined policies
+// for one source/destination zone pair, split around the system-defined ones.
+type FirewallPolicyOrdering struct {
+	BeforeSystemDefined []string `json:"beforeSystemDefined"`
+	AfterSystemDefined  []string `json:"afterSystemDefined"`
+}
+
+type firewallPolicyOrderingBody struct {
+	OrderedFirewallPolicyIDs FirewallPolicyOrdering `json:"orderedFirewallPolicyIds"`
+}
+
+func (c *Client) firewallPolicyOrderingURL(ctx context.Context, sourceZoneID, destinationZoneID string) string {
+	return fmt.Sprintf("%s/v1/sites/%s/firewall/policies/ordering?sourceFirewallZoneId=%s&destinationFirewallZoneId=%s",
+		c.BaseURL, c.siteID(ctx), sourceZoneID, destinationZoneID)
+}
+
+func (c *Client) GetFirewallPolicyOrdering(ctx context.Context, sourceZoneID, destinationZoneID string) (*FirewallPolicyOrdering, error) {
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, c.firewallPolicyOrderingURL(ctx, sourceZoneID, destinationZoneID), nil)
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result firewallPolicyOrderingBody
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result.OrderedFirewallPolicyIDs, nil
+}
+
+// UpdateFirewallPolicyOrdering replaces the policy order for a zone pair. The
+// ordering must list every user-defined policy of the pair exactly once.
+func (c *Client) UpdateFirewallPolicyOrdering(ctx context.Context, sourceZoneID, destinationZoneID string, ordering FirewallPolicyOrdering) (*FirewallPolicyOrdering, error) {
+	payload, _ := json.Marshal(firewallPolicyOrderingBody{OrderedFirewallPolicyIDs: ordering})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPut, c.firewallPolicyOrderingURL(ctx, sourceZoneID, destinationZoneID), bytes.NewBuffer(payload))
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result firewallPolicyOrderingBody
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	c.invalidateFWPolicyCache(c.siteID(ctx))
+	return &result.OrderedFirewallPolicyIDs, nil
+}
+
+// Traffic Matching Lists
+type TrafficMatchingList struct {
+	ID    string                    `json:"id,omitempty"`
+	Type  string                    `json:"type"` // PORTS, IPV4_ADDRESSES, IPV6_ADDRESSES
+	Name  string                    `json:"name"`
+	Items []TrafficMatchingListItem `json:"items"`
+}
+
+type TrafficMatchingListItem struct {
+	Type  string      `json:"type"`            // PORT_NUMBER, PORT_NUMBER_RANGE, IP_ADDRESS, SUBNET, IP_ADDRESS_RANGE
+	Value interface{} `json:"value,omitempty"` // Polymorphic: port number or address/subnet string
+	Start interface{} `json:"start,omitempty"` // range start, same type as Value
+	Stop  interface{} `json:"stop,omitempty"`  // range stop, same type as Value
+}
+
+func (c *Client) ListTrafficMatchingLists(ctx context.Context) ([]TrafficMatchingList, error) {
+	site := c.siteID(ctx)
+	c.mu.Lock()
+	if lists, ok := c.trafficListCache.get(site); ok {
+		c.mu.Unlock()
+		return lists, nil
+	}
+	c.mu.Unlock()
+
+	v, err := c.doShared(ctx, sharedKey("traffic-matching-lists", site), func() (interface{}, error) {
+		var allLists []TrafficMatchingList
+		offset := 0
+		const pageSize = 200
+
+		for {
+			url := fmt.Sprintf("%s/v1/sites/%s/traffic-matching-lists?limit=%d&offset=%d", c.BaseURL, site, pageSize, offset)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+			body, err := c.doRequest(req)
+			if err != nil {
+				return nil, err
+			}
+
+			var response struct {
+				Data []TrafficMatchingList `json:"data"`
+			}
+			if err := json.Unmarshal(body, &response); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal traffic matching lists: %w. response body: %s", err, string(body))
+			}
+
+			allLists = append(allLists, response.Data...)
+			if len(response.Data) < pageSize {
+				break
+			}
+			offset += pageSize
+		}
+
+		c.mu.Lock()
+		c.trafficListCache.put(site, allLists, c.CacheTTL)
+		c.mu.Unlock()
+
+		return allLists, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]TrafficMatchingList), nil
+}
+
+func (c *Client) CreateTrafficMatchingList(ctx context.Context, list TrafficMatchingList) (*TrafficMatchingList, error) {
+	url := fmt.Sprintf("%s/v1/sites/%s/traffic-matching-lists", c.BaseURL, c.siteID(ctx))
+	payload, _ := json.Marshal(list)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload))
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result TrafficMatchingList
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	c.invalidateTrafficListCache(c.siteID(ctx))
+	return &result, nil
+}
+
+// GetTrafficMatchingList retrieves a single list, preferring the cached list
+// of lists and falling back to a direct GET.
+func (c *Client) GetTrafficMatchingList(ctx context.Context, listID string) (*TrafficMatchingList, error) {
+	lists, err := c.ListTrafficMatchingLists(ctx)
+	if err == nil {
+		for i := range lists {
+			if lists[i].ID == listID {
+				return &lists[i], nil
+			}
+		}
+	}
+
+	url := fmt.Sprintf("%s/v1/sites/%s/traffic-matching-lists/%s", c.BaseURL, c.siteID(ctx), listID)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result TrafficMatchingList
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) UpdateTrafficMatchingList(ctx context.Context, listID string, list TrafficMatchingList) (*TrafficMatchingList, error) {
+	url := fmt.Sprintf("%s/v1/sites/%s/traffic-matching-lists/%s", c.BaseURL, c.siteID(ctx), listID)
+	payload, _ := json.Marshal(list)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewBuffer(payload))
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result TrafficMatchingList
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	c.invalidateTrafficListCache(c.siteID(ctx))
+	return &result, nil
+}
+
+func (c *Client) DeleteTrafficMatchingList(ctx context.Context, listID string) error {
+	url := fmt.Sprintf("%s/v1/sites/%s/traffic-matching-lists/%s", c.BaseURL, c.siteID(ctx), listID)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	_, err := c.doRequest(req)
+	c.invalidateTrafficListCache(c.siteID(ctx))
 	return err
 }
 
 // Networks
 type Network struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	VlanID     int    `json:"vlanId"`
-	Management string `json:"management"`
+	ID                string                    `json:"id,omitempty"`
+	Name              string                    `json:"name"`
+	VlanID            int                       `json:"vlanId"`
+	Management        string                    `json:"management"` // GATEWAY, SWITCH, UNMANAGED
+	Enabled           bool                      `json:"enabled"`
+	IsolationEnabled  bool                      `json:"isolationEnabled"`
+	ZoneID            string                    `json:"zoneId,omitempty"`
+	IPv4Configuration *NetworkIPv4Configuration `json:"ipv4Configuration,omitempty"`
+	IPv6Configuration *NetworkIPv6Configuration `json:"ipv6Configuration,omitempty"`
+}
+
+type NetworkIPv4Configuration struct {
+	HostIPAddress     string                    `json:"hostIpAddress"`
+	PrefixLength      int                       `json:"prefixLength"`
+	DHCPConfiguration *NetworkDHCPConfiguration `json:"dhcpConfiguration,omitempty"`
+}
+
+type NetworkDHCPConfiguration struct {
+	Mode                         string          `json:"mode"` // SERVER, NONE
+	IPAddressRange               *NetworkIPRange `json:"ipAddressRange,omitempty"`
+	LeaseTimeSeconds             int             `json:"leaseTimeSeconds,omitempty"`
+	DNSServerIPAddressesOverride []string        `json:"dnsServerIpAddressesOverride,omitempty"`
+	DomainName                   string          `json:"domainName,omitempty"`
+}
+
+type NetworkIPRange struct {
+	Start string `json:"start"`
+	Stop  string `json:"stop"`
+}
+
+type NetworkIPv6Configuration struct {
+	InterfaceType string `json:"interfaceType"` // PREFIX_DELEGATION, STATIC
 }
 
-func (c *Client) ListNetworks() ([]Network, error) {
+func (c *Client) ListNetworks(ctx context.Context) ([]Network, error) {
+	site := c.siteID(ctx)
 	c.mu.Lock()
-	if c.networkCache != nil && c.networkCache.valid() {
-		networks := c.networkCache.data
+	if networks, ok := c.networkCache.get(site); ok {
 		c.mu.Unlock()
 		return networks, nil
 	}
 	c.mu.Unlock()
 
-	v, err, _ := c.sf.Do("networks", func() (interface{}, error) {
+	v, err := c.doShared(ctx, sharedKey("networks", site), func() (interface{}, error) {
 		var allNetworks []Network
 		offset := 0
 		const pageSize = 200
 
 		for {
-			url := fmt.Sprintf("%s/v1/sites/%s/networks?limit=%d&offset=%d", c.BaseURL, c.SiteID, pageSize, offset)
-			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			url := fmt.Sprintf("%s/v1/sites/%s/networks?limit=%d&offset=%d", c.BaseURL, site, pageSize, offset)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
 			body, err := c.doRequest(req)
 			if err != nil {
@@ -554,7 +1094,7 @@ func (c *Client) ListNetworks() ([]Network, error) {
 		}
 
 		c.mu.Lock()
-		c.networkCache = &cacheEntry[[]Network]{data: allNetworks, expiresAt: time.Now().Add(cacheTTL)}
+		c.networkCache.put(site, allNetworks, c.CacheTTL)
 		c.mu.Unlock()
 
 		return allNetworks, nil
@@ -565,6 +1105,80 @@ func (c *Client) ListNetworks() ([]Network, error) {
 	return v.([]Network), nil
 }
 
+func (c *Client) CreateNetwork(ctx context.Context, network Network) (*Network, error) {
+	url := fmt.Sprintf("%s/v1/sites/%s/networks", c.BaseURL, c.siteID(ctx))
+	payload, _ := json.Marshal(network)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload))
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Network
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	c.invalidateNetworkCache(c.siteID(ctx))
+	return &result, nil
+}
+
+// GetNetwork retrieves a single network, preferring the cached network list
+// and falling back to a direct GET.
+func (c *Client) GetNetwork(ctx context.Context, networkID string) (*Network, error) {
+	networks, err := c.ListNetworks(ctx)
+	if err == nil {
+		for i := range networks {
+			if networks[i].ID == networkID {
+				return &networks[i], nil
+			}
+		}
+	}
+
+	url := fmt.Sprintf("%s/v1/sites/%s/networks/%s", c.BaseURL, c.siteID(ctx), networkID)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Network
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) UpdateNetwork(ctx context.Context, networkID string, network Network) (*Network, error) {
+	url := fmt.Sprintf("%s/v1/sites/%s/networks/%s", c.BaseURL, c.siteID(ctx), networkID)
+	payload, _ := json.Marshal(network)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewBuffer(payload))
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Network
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	c.invalidateNetworkCache(c.siteID(ctx))
+	return &result, nil
+}
+
+func (c *Client) DeleteNetwork(ctx context.Context, networkID string) error {
+	url := fmt.Sprintf("%s/v1/sites/%s/networks/%s", c.BaseURL, c.siteID(ctx), networkID)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	_, err := c.doRequest(req)
+	c.invalidateNetworkCache(c.siteID(ctx))
+	return err
+}
+
 // DNS Policies
 type DNSPolicy struct {
 	ID      string `json:"id,omitempty"`
@@ -598,23 +1212,22 @@ type DNSPolicy struct {
 
 // ListDNSPolicies fetches all DNS policies for the given site, using a short-lived cache.
 // The siteID parameter makes this safe for concurrent use without mutating Client state.
-func (c *Client) ListDNSPolicies(siteID string) ([]DNSPolicy, error) {
+func (c *Client) ListDNSPolicies(ctx context.Context, siteID string) ([]DNSPolicy, error) {
 	c.mu.Lock()
-	if c.dnsPolicyCache != nil && c.dnsPolicyCache.valid() {
-		policies := c.dnsPolicyCache.data
+	if policies, ok := c.dnsPolicyCache.get(siteID); ok {
 		c.mu.Unlock()
 		return policies, nil
 	}
 	c.mu.Unlock()
 
-	v, err, _ := c.sf.Do("dns-policies", func() (interface{}, error) {
+	v, err := c.doShared(ctx, sharedKey("dns-policies", siteID), func() (interface{}, error) {
 		var allPolicies []DNSPolicy
 		offset := 0
 		const pageSize = 200
 
 		for {
 			url := fmt.Sprintf("%s/v1/sites/%s/dns/policies?limit=%d&offset=%d", c.BaseURL, siteID, pageSize, offset)
-			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
 			body, err := c.doRequest(req)
 			if err != nil {
@@ -636,7 +1249,7 @@ func (c *Client) ListDNSPolicies(siteID string) ([]DNSPolicy, error) {
 		}
 
 		c.mu.Lock()
-		c.dnsPolicyCache = &cacheEntry[[]DNSPolicy]{data: allPolicies, expiresAt: time.Now().Add(cacheTTL)}
+		c.dnsPolicyCache.put(siteID, allPolicies, c.CacheTTL)
 		c.mu.Unlock()
 
 		return allPolicies, nil
@@ -647,10 +1260,10 @@ func (c *Client) ListDNSPolicies(siteID string) ([]DNSPolicy, error) {
 	return v.([]DNSPolicy), nil
 }
 
-func (c *Client) CreateDNSPolicy(siteID string, policy DNSPolicy) (*DNSPolicy, error) {
+func (c *Client) CreateDNSPolicy(ctx context.Context, siteID string, policy DNSPolicy) (*DNSPolicy, error) {
 	url := fmt.Sprintf("%s/v1/sites/%s/dns/policies", c.BaseURL, siteID)
 	payload, _ := json.Marshal(policy)
-	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(payload))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload))
 
 	body, err := c.doRequest(req)
 	if err != nil {
@@ -662,14 +1275,14 @@ func (c *Client) CreateDNSPolicy(siteID string, policy DNSPolicy) (*DNSPolicy, e
 		return nil, err
 	}
 
-	c.invalidateDNSPolicyCache()
+	c.invalidateDNSPolicyCache(siteID)
 	return &result, nil
 }
 
 // GetDNSPolicy retrieves a single DNS policy. Uses the cached list when available.
 // The siteID parameter makes this safe for concurrent use without mutating Client state.
-func (c *Client) GetDNSPolicy(siteID, policyId string) (*DNSPolicy, error) {
-	policies, err := c.ListDNSPolicies(siteID)
+func (c *Client) GetDNSPolicy(ctx context.Context, siteID, policyId string) (*DNSPolicy, error) {
+	policies, err := c.ListDNSPolicies(ctx, siteID)
 	if err == nil {
 		for i := range policies {
 			if policies[i].ID == policyId {
@@ -680,7 +1293,7 @@ func (c *Client) GetDNSPolicy(siteID, policyId string) (*DNSPolicy, error) {
 
 	// Fallback: direct GET.
 	url := fmt.Sprintf("%s/v1/sites/%s/dns/policies/%s", c.BaseURL, siteID, policyId)
-	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
 	body, err := c.doRequest(req)
 	if err != nil {
@@ -695,10 +1308,10 @@ func (c *Client) GetDNSPolicy(siteID, policyId string) (*DNSPolicy, error) {
 	return &result, nil
 }
 
-func (c *Client) UpdateDNSPolicy(siteID, policyId string, policy DNSPolicy) (*DNSPolicy, error) {
+func (c *Client) UpdateDNSPolicy(ctx context.Context, siteID, policyId string, policy DNSPolicy) (*DNSPolicy, error) {
 	url := fmt.Sprintf("%s/v1/sites/%s/dns/policies/%s", c.BaseURL, siteID, policyId)
 	payload, _ := json.Marshal(policy)
-	req, _ := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(payload))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewBuffer(payload))
 
 	body, err := c.doRequest(req)
 	if err != nil {
@@ -710,18 +1323,245 @@ func (c *Client) UpdateDNSPolicy(siteID, policyId string, policy DNSPolicy) (*DN
 		return nil, err
 	}
 
-	c.invalidateDNSPolicyCache()
+	c.invalidateDNSPolicyCache(siteID)
 	return &result, nil
 }
 
-func (c *Client) DeleteDNSPolicy(siteID, policyId string) error {
+func (c *Client) DeleteDNSPolicy(ctx context.Context, siteID, policyId string) error {
 	url := fmt.Sprintf("%s/v1/sites/%s/dns/policies/%s", c.BaseURL, siteID, policyId)
-	req, _ := http.NewRequest(http.MethodDelete, url, nil)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	_, err := c.doRequest(req)
-	c.invalidateDNSPolicyCache()
+	c.invalidateDNSPolicyCache(siteID)
 	return err
 }
 
+// VPN
+//
+// VPN servers and site-to-site tunnels are configured in the UI; the provider
+// only reads them so policies can target them.
+type VPNServer struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"` // e.g. WIREGUARD, OPENVPN, L2TP
+	Enabled bool   `json:"enabled"`
+}
+
+type SiteToSiteVPNTunnel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"` // e.g. IPSEC, OPENVPN, WIREGUARD
+}
+
+func (c *Client) ListVPNServers(ctx context.Context) ([]VPNServer, error) {
+	site := c.siteID(ctx)
+	c.mu.Lock()
+	if servers, ok := c.vpnServerCache.get(site); ok {
+		c.mu.Unlock()
+		return servers, nil
+	}
+	c.mu.Unlock()
+
+	v, err := c.doShared(ctx, sharedKey("vpn-servers", site), func() (interface{}, error) {
+		var allServers []VPNServer
+		offset := 0
+		const pageSize = 200
+
+		for {
+			url := fmt.Sprintf("%s/v1/sites/%s/vpn/servers?limit=%d&offset=%d", c.BaseURL, site, pageSize, offset)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+			body, err := c.doRequest(req)
+			if err != nil {
+				return nil, err
+			}
+
+			var response struct {
+				Data []VPNServer `json:"data"`
+			}
+			if err := json.Unmarshal(body, &response); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal VPN servers: %w. response body: %s", err, string(body))
+			}
+
+			allServers = append(allServers, response.Data...)
+			if len(response.Data) < pageSize {
+				break
+			}
+			offset += pageSize
+		}
+
+		c.mu.Lock()
+		c.vpnServerCache.put(site, allServers, c.CacheTTL)
+		c.mu.Unlock()
+
+		return allServers, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]VPNServer), nil
+}
+
+func (c *Client) ListSiteToSiteVPNTunnels(ctx context.Context) ([]SiteToSiteVPNTunnel, error) {
+	site := c.siteID(ctx)
+	c.mu.Lock()
+	if tunnels, ok := c.vpnTunnelCache.get(site); ok {
+		c.mu.Unlock()
+		return tunnels, nil
+	}
+	c.mu.Unlock()
+
+	v, err := c.doShared(ctx, sharedKey("vpn-tunnels", site), func() (interface{}, error) {
+		var allTunnels []SiteToSiteVPNTunnel
+		offset := 0
+		const pageSize = 200
+
+		for {
+			url := fmt.Sprintf("%s/v1/sites/%s/vpn/site-to-site-tunnels?limit=%d&offset=%d", c.BaseURL, site, pageSize, offset)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+			body, err := c.doRequest(req)
+			if err != nil {
+				return nil, err
+			}
+
+			var response struct {
+				Data []SiteToSiteVPNTunnel `json:"data"`
+			}
+			if err := json.Unmarshal(body, &response); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal site-to-site VPN tunnels: %w. response body: %s", err, string(body))
+			}
+
+			allTunnels = append(allTunnels, response.Data...)
+			if len(response.Data) < pageSize {
+				break
+			}
+			offset += pageSize
+		}
+
+		c.mu.Lock()
+		c.vpnTunnelCache.put(site, allTunnels, c.CacheTTL)
+		c.mu.Unlock()
+
+		return allTunnels, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]SiteToSiteVPNTunnel), nil
+}
+
+// DPI catalog
+//
+// The application and category catalog is global to the controller, not
+// per site, and is read-only.
+type DPIApplication struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type DPICategory struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (c *Client) ListDPIApplications(ctx context.Context) ([]DPIApplication, error) {
+	c.mu.Lock()
+	if c.dpiAppCache != nil && c.dpiAppCache.valid() {
+		apps := c.dpiAppCache.data
+		c.mu.Unlock()
+		return apps, nil
+	}
+	c.mu.Unlock()
+
+	v, err := c.doShared(ctx, "dpi-applications", func() (interface{}, error) {
+		var allApps []DPIApplication
+		offset := 0
+		const pageSize = 200
+
+		for {
+			url := fmt.Sprintf("%s/v1/dpi/applications?limit=%d&offset=%d", c.BaseURL, pageSize, offset)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+			body, err := c.doRequest(req)
+			if err != nil {
+				return nil, err
+			}
+
+			var response struct {
+				Data []DPIApplication `json:"data"`
+			}
+			if err := json.Unmarshal(body, &response); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal DPI applications: %w. response body: %s", err, string(body))
+			}
+
+			allApps = append(allApps, response.Data...)
+			if len(response.Data) < pageSize {
+				break
+			}
+			offset += pageSize
+		}
+
+		c.mu.Lock()
+		c.dpiAppCache = &cacheEntry[[]DPIApplication]{data: allApps, expiresAt: time.Now().Add(c.CacheTTL)}
+		c.mu.Unlock()
+
+		return allApps, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]DPIApplication), nil
+}
+
+func (c *Client) ListDPICategories(ctx context.Context) ([]DPICategory, error) {
+	c.mu.Lock()
+	if c.dpiCategoryCache != nil && c.dpiCategoryCache.valid() {
+		categories := c.dpiCategoryCache.data
+		c.mu.Unlock()
+		return categories, nil
+	}
+	c.mu.Unlock()
+
+	v, err := c.doShared(ctx, "dpi-categories", func() (interface{}, error) {
+		var allCategories []DPICategory
+		offset := 0
+		const pageSize = 200
+
+		for {
+			url := fmt.Sprintf("%s/v1/dpi/categories?limit=%d&offset=%d", c.BaseURL, pageSize, offset)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+			body, err := c.doRequest(req)
+			if err != nil {
+				return nil, err
+			}
+
+			var response struct {
+				Data []DPICategory `json:"data"`
+			}
+			if err := json.Unmarshal(body, &response); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal DPI categories: %w. response body: %s", err, string(body))
+			}
+
+			allCategories = append(allCategories, response.Data...)
+			if len(response.Data) < pageSize {
+				break
+			}
+			offset += pageSize
+		}
+
+		c.mu.Lock()
+		c.dpiCategoryCache = &cacheEntry[[]DPICategory]{data: allCategories, expiresAt: time.Now().Add(c.CacheTTL)}
+		c.mu.Unlock()
+
+		return allCategories, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]DPICategory), nil
+}
+
 // Client Devices (for fixed IP / DHCP reservations)
 //
 // Client operations use the legacy REST API (/api/s/{site}/rest/user) instead
@@ -746,17 +1586,17 @@ type restAPIResponse struct {
 	Data json.RawMessage `json:"data"`
 }
 
-func (c *Client) restUserURL(objectID ...string) string {
-	base := fmt.Sprintf("%s/api/s/%s/rest/user", c.networkBaseURL(), c.SiteReference)
+func (c *Client) restUserURL(ctx context.Context, objectID ...string) string {
+	base := fmt.Sprintf("%s/api/s/%s/rest/user", c.networkBaseURL(), c.siteReference(ctx))
 	if len(objectID) > 0 && objectID[0] != "" {
 		return base + "/" + objectID[0]
 	}
 	return base
 }
 
-func (c *Client) ListClients(_ string) ([]ClientDevice, error) {
-	url := c.restUserURL()
-	req, _ := http.NewRequest(http.MethodGet, url, nil)
+func (c *Client) ListClients(ctx context.Context, _ string) ([]ClientDevice, error) {
+	url := c.restUserURL(ctx)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
 	body, err := c.doRequest(req)
 	if err != nil {
@@ -779,9 +1619,9 @@ func (c *Client) ListClients(_ string) ([]ClientDevice, error) {
 	return clients, nil
 }
 
-func (c *Client) GetClient(_ string, clientID string) (*ClientDevice, error) {
-	url := c.restUserURL(clientID)
-	req, _ := http.NewRequest(http.MethodGet, url, nil)
+func (c *Client) GetClient(ctx context.Context, _ string, clientID string) (*ClientDevice, error) {
+	url := c.restUserURL(ctx, clientID)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
 	body, err := c.doRequest(req)
 	if err != nil {
@@ -801,14 +1641,14 @@ func (c *Client) GetClient(_ string, clientID string) (*ClientDevice, error) {
 		return nil, fmt.Errorf("failed to unmarshal client data: %w", err)
 	}
 	if len(clients) == 0 {
-		return nil, fmt.Errorf("client %q not found", clientID)
+		return nil, &APIError{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("client %q not found", clientID)}
 	}
 
 	return &clients[0], nil
 }
 
-func (c *Client) SetClientFixedIP(_ string, clientID, networkID, fixedIP, name string) (*ClientDevice, error) {
-	url := c.restUserURL(clientID)
+func (c *Client) SetClientFixedIP(ctx context.Context, _ string, clientID, networkID, fixedIP, name string) (*ClientDevice, error) {
+	url := c.restUserURL(ctx, clientID)
 	update := ClientDevice{
 		UseFixedIP: true,
 		NetworkID:  networkID,
@@ -816,7 +1656,7 @@ func (c *Client) SetClientFixedIP(_ string, clientID, networkID, fixedIP, name s
 		Name:       name,
 	}
 	payload, _ := json.Marshal(update)
-	req, _ := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(payload))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewBuffer(payload))
 
 	body, err := c.doRequest(req)
 	if err != nil {
@@ -842,13 +1682,13 @@ func (c *Client) SetClientFixedIP(_ string, clientID, networkID, fixedIP, name s
 	return &clients[0], nil
 }
 
-func (c *Client) UnsetClientFixedIP(_ string, clientID string) error {
-	url := c.restUserURL(clientID)
+func (c *Client) UnsetClientFixedIP(ctx context.Context, _ string, clientID string) error {
+	url := c.restUserURL(ctx, clientID)
 	update := map[string]interface{}{
 		"use_fixedip": false,
 	}
 	payload, _ := json.Marshal(update)
-	req, _ := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(payload))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewBuffer(payload))
 
 	_, err := c.doRequest(req)
 	return err
@@ -0,0 +1,212 @@
+package unifi
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newSessionClient(t *testing.T, baseURL string) *Client {
+	t.Helper()
+	client, err := NewClientWithCredentials(context.Background(), baseURL, "admin", "password", "site-1", false)
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	client.Retry = fastRetry(1)
+	return client
+}
+
+func TestSession_ReloginAfterExpiry(t *testing.T) {
+	srv, mock := newMockServer(t)
+	mock.EnforceSession()
+	client := newSessionClient(t, srv.URL)
+
+	if _, err := client.ListSites(context.Background()); err != nil {
+		t.Fatalf("ListSites with fresh session: %v", err)
+	}
+
+	mock.ExpireSession()
+
+	sites, err := client.ListSites(context.Background())
+	if err != nil {
+		t.Fatalf("ListSites after expiry: %v", err)
+	}
+	if len(sites) != 1 {
+		t.Errorf("expected 1 site, got %d", len(sites))
+	}
+	if got := mock.GetCallCount("POST", "/api/login"); got != 2 {
+		t.Errorf("expected 2 logins, got %d", got)
+	}
+	if got := mock.GetCallCount("GET", "/v1/sites"); got != 3 {
+		t.Errorf("expected 3 site requests (ok, 401, replay), got %d", got)
+	}
+}
+
+func TestSession_ReloginReplaysBody(t *testing.T) {
+	srv, mock := newMockServer(t)
+	mock.EnforceSession()
+	client := newSessionClient(t, srv.URL)
+	mock.ExpireSession()
+
+	created, err := client.CreateFirewallPolicy(context.Background(), FirewallPolicy{Name: "Allow SSH"})
+	if err != nil {
+		t.Fatalf("CreateFirewallPolicy after expiry: %v", err)
+	}
+	if created.Name != "Allow SSH" {
+		t.Errorf("expected replayed body to keep name 'Allow SSH', got %q", created.Name)
+	}
+	if got := len(mock.fwPolicies["site-1"]); got != 1 {
+		t.Errorf("expected 1 stored policy, got %d", got)
+	}
+}
+
+func TestSession_ConcurrentExpiryLogsInOnce(t *testing.T) {
+	srv, mock := newMockServer(t)
+	mock.EnforceSession()
+	client := newSessionClient(t, srv.URL)
+	mock.ExpireSession()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.ListSites(context.Background()); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("ListSites: %v", err)
+	}
+	if got := mock.GetCallCount("POST", "/api/login"); got != 2 {
+		t.Errorf("expected a single re-login (2 logins total), got %d", got)
+	}
+}
+
+func TestSession_ReloginFailure(t *testing.T) {
+	srv, mock := newMockServer(t)
+	mock.EnforceSession()
+	client := newSessionClient(t, srv.URL)
+	mock.ExpireSession()
+	client.password = "wrong"
+
+	_, err := client.ListSites(context.Background())
+	if err == nil {
+		t.Fatal("expected error when re-login fails")
+	}
+	if !strings.Contains(err.Error(), "re-login failed") {
+		t.Errorf("expected re-login failure in error, got: %v", err)
+	}
+	if IsNotFound(err) {
+		t.Error("a failed re-login must not look like a 404")
+	}
+}
+
+func TestSession_PersistentUnauthorizedNotLooped(t *testing.T) {
+	srv, mock := newMockServer(t)
+	client := newSessionClient(t, srv.URL)
+	mock.SetError("GET", "/v1/sites", 401)
+
+	if _, err := client.ListSites(context.Background()); err == nil {
+		t.Fatal("expected error for persistent 401")
+	}
+	if got := mock.GetCallCount("GET", "/v1/sites"); got != 2 {
+		t.Errorf("expected original request plus one replay, got %d", got)
+	}
+	if got := mock.GetCallCount("POST", "/api/login"); got != 2 {
+		t.Errorf("expected exactly one re-login, got %d logins", got)
+	}
+}
+
+func TestSession_APIKeyNeverLogsIn(t *testing.T) {
+	srv, mock := newMockServer(t)
+	client := NewClient(srv.URL, "key", "site-1", false)
+	client.Retry = fastRetry(0)
+	mock.SetError("GET", "/v1/sites", 401)
+
+	if _, err := client.ListSites(context.Background()); err == nil {
+		t.Fatal("expected error for 401")
+	}
+	if got := mock.GetCallCount("POST", "/api/login"); got != 0 {
+		t.Errorf("expected no login in API key mode, got %d", got)
+	}
+}
+
+func TestSession_UpdatedCSRFToken(t *testing.T) {
+	srv, mock := newMockServer(t)
+	client := newSessionClient(t, srv.URL)
+	mock.SetUpdatedCSRFToken("rotated-csrf-token")
+
+	if _, err := client.ListSites(context.Background()); err != nil {
+		t.Fatalf("ListSites: %v", err)
+	}
+	if client.csrfToken != "rotated-csrf-token" {
+		t.Errorf("expected rotated csrf token, got %q", client.csrfToken)
+	}
+}
+
+func TestLogin_UniFiOSEndpoint(t *testing.T) {
+	srv, mock := newMockServer(t)
+	mock.SetLegacyLoginMissing()
+
+	client := newSessionClient(t, srv.URL+"/proxy/network/integration")
+
+	if got := mock.GetCallCount("POST", "/api/auth/login"); got != 1 {
+		t.Errorf("expected 1 UniFi OS login, got %d", got)
+	}
+	if got := mock.GetCallCount("POST", "/api/login"); got != 0 {
+		t.Errorf("expected no legacy login attempt, got %d", got)
+	}
+	if client.csrfToken != "mock-csrf-token" {
+		t.Errorf("expected csrf token 'mock-csrf-token', got %q", client.csrfToken)
+	}
+	if _, err := client.ListSites(context.Background()); err != nil {
+		t.Fatalf("ListSites: %v", err)
+	}
+}
+
+func TestLogin_FallsBackToUniFiOSEndpoint(t *testing.T) {
+	srv, mock := newMockServer(t)
+	mock.SetLegacyLoginMissing()
+
+	client := newSessionClient(t, srv.URL)
+
+	if got := mock.GetCallCount("POST", "/api/login"); got != 1 {
+		t.Errorf("expected 1 legacy login attempt, got %d", got)
+	}
+	if got := mock.GetCallCount("POST", "/api/auth/login"); got != 1 {
+		t.Errorf("expected fallback to /api/auth/login, got %d", got)
+	}
+	if !strings.HasSuffix(client.loginURL, "/api/auth/login") {
+		t.Errorf("expected remembered login URL to be /api/auth/login, got %q", client.loginURL)
+	}
+}
+
+func TestLoginURLs(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    []string
+	}{
+		{
+			baseURL: "https://udm.local/proxy/network/integration",
+			want:    []string{"https://udm.local/api/auth/login", "https://udm.local/proxy/network/api/login"},
+		},
+		{
+			baseURL: "https://controller:8443/integration",
+			want:    []string{"https://controller:8443/api/login", "https://controller:8443/api/auth/login"},
+		},
+	}
+	for _, tt := range tests {
+		c := &Client{BaseURL: tt.baseURL}
+		got := c.loginURLs()
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("loginURLs(%q) = %v, want %v", tt.baseURL, got, tt.want)
+		}
+	}
+}
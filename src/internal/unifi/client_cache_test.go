@@ -1,9 +1,11 @@
 package unifi
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -46,7 +48,7 @@ func TestListFirewallZones_CachesResponse(t *testing.T) {
 	client := NewClient(srv.URL, "key", "site1", false)
 
 	// First call should hit the server.
-	result1, err := client.ListFirewallZones()
+	result1, err := client.ListFirewallZones(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -55,7 +57,7 @@ func TestListFirewallZones_CachesResponse(t *testing.T) {
 	}
 
 	// Second call should use cache, not hit server again.
-	result2, err := client.ListFirewallZones()
+	result2, err := client.ListFirewallZones(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -82,7 +84,7 @@ func TestListNetworks_CachesResponse(t *testing.T) {
 	client := NewClient(srv.URL, "key", "site1", false)
 
 	for i := 0; i < 5; i++ {
-		result, err := client.ListNetworks()
+		result, err := client.ListNetworks(context.Background())
 		if err != nil {
 			t.Fatalf("call %d: unexpected error: %v", i, err)
 		}
@@ -110,7 +112,7 @@ func TestListFirewallPolicies_CachesResponse(t *testing.T) {
 	client := NewClient(srv.URL, "key", "site1", false)
 
 	for i := 0; i < 3; i++ {
-		result, err := client.ListFirewallPolicies()
+		result, err := client.ListFirewallPolicies(context.Background())
 		if err != nil {
 			t.Fatalf("call %d: unexpected error: %v", i, err)
 		}
@@ -140,7 +142,7 @@ func TestGetFirewallPolicy_UsesCachedList(t *testing.T) {
 
 	// Get three different policies — should only trigger 1 list call.
 	for _, id := range []string{"p1", "p2", "p3"} {
-		result, err := client.GetFirewallPolicy(id)
+		result, err := client.GetFirewallPolicy(context.Background(), id)
 		if err != nil {
 			t.Fatalf("GetFirewallPolicy(%s): unexpected error: %v", id, err)
 		}
@@ -168,7 +170,7 @@ func TestGetDNSPolicy_UsesCachedList(t *testing.T) {
 	client := NewClient(srv.URL, "key", "site1", false)
 
 	for _, id := range []string{"d1", "d2"} {
-		result, err := client.GetDNSPolicy("site1", id)
+		result, err := client.GetDNSPolicy(context.Background(), "site1", id)
 		if err != nil {
 			t.Fatalf("GetDNSPolicy(%s): unexpected error: %v", id, err)
 		}
@@ -195,13 +197,13 @@ func TestInvalidateCache_ClearsAllCaches(t *testing.T) {
 	client := NewClient(srv.URL, "key", "site1", false)
 
 	// Populate caches.
-	client.ListFirewallZones()
-	client.ListNetworks()
+	client.ListFirewallZones(context.Background())
+	client.ListNetworks(context.Background())
 
 	// Invalidate and call again — should hit server again.
 	client.InvalidateCache()
-	client.ListFirewallZones()
-	client.ListNetworks()
+	client.ListFirewallZones(context.Background())
+	client.ListNetworks(context.Background())
 
 	zoneCount := counts["/v1/sites/site1/firewall/zones"].Load()
 	netCount := counts["/v1/sites/site1/networks"].Load()
@@ -223,15 +225,15 @@ func TestCacheExpiry(t *testing.T) {
 	client := NewClient(srv.URL, "key", "site1", false)
 
 	// Populate cache.
-	client.ListFirewallZones()
+	client.ListFirewallZones(context.Background())
 
 	// Manually expire the cache.
 	client.mu.Lock()
-	client.zoneCache.expiresAt = time.Now().Add(-1 * time.Second)
+	client.zoneCache["site1"].expiresAt = time.Now().Add(-1 * time.Second)
 	client.mu.Unlock()
 
 	// Should refetch.
-	client.ListFirewallZones()
+	client.ListFirewallZones(context.Background())
 
 	callCount := counts["/v1/sites/site1/firewall/zones"].Load()
 	if callCount != 2 {
@@ -265,19 +267,134 @@ func TestMutationInvalidatesCache(t *testing.T) {
 	client := NewClient(srv.URL, "key", "site1", false)
 
 	// Populate cache.
-	client.ListFirewallPolicies()
+	client.ListFirewallPolicies(context.Background())
 	if counts["/v1/sites/site1/firewall/policies"].Load() != 1 {
 		t.Fatal("expected 1 initial call")
 	}
 
 	// Create invalidates cache.
-	client.CreateFirewallPolicy(FirewallPolicy{Name: "New Policy"})
+	client.CreateFirewallPolicy(context.Background(), FirewallPolicy{Name: "New Policy"})
 
 	// Next list should refetch.
-	client.ListFirewallPolicies()
+	client.ListFirewallPolicies(context.Background())
 	callCount := counts["/v1/sites/site1/firewall/policies"].Load()
 	// 1 (initial list) + 1 (create POST) + 1 (refetch after invalidation) = 3
 	if callCount != 3 {
 		t.Errorf("expected 3 API calls (list + create + refetch), got %d", callCount)
 	}
 }
+
+func TestZoneMembershipInvalidatesNetworkCache(t *testing.T) {
+	srv, counts := newTestServer(t, map[string]interface{}{
+		"/v1/sites/site1/networks":             map[string]interface{}{"data": []Network{{ID: "net-1", Name: "IoT"}}},
+		"/v1/sites/site1/firewall/zones":       map[string]interface{}{},
+		"/v1/sites/site1/firewall/zones/zone1": map[string]interface{}{},
+	})
+	defer srv.Close()
+	client := NewClient(srv.URL, "key", "site1", false)
+	ctx := context.Background()
+	networks := counts["/v1/sites/site1/networks"]
+
+	client.ListNetworks(ctx)
+	client.CreateFirewallZone(ctx, FirewallZone{Name: "Empty"})
+	client.ListNetworks(ctx)
+	if networks.Load() != 1 {
+		t.Errorf("expected a zone without networks to keep the network cache, got %d list calls", networks.Load())
+	}
+
+	mutations := map[string]func(){
+		"create": func() { client.CreateFirewallZone(ctx, FirewallZone{Name: "IoT", NetworkIDs: []string{"net-1"}}) },
+		"update": func() { client.UpdateFirewallZone(ctx, "zone1", FirewallZone{Name: "IoT"}) },
+		"delete": func() { client.DeleteFirewallZone(ctx, "zone1") },
+	}
+	for name, mutate := range mutations {
+		before := networks.Load()
+		mutate()
+		client.ListNetworks(ctx)
+		if networks.Load() != before+1 {
+			t.Errorf("%s: expected the network list to be refetched", name)
+		}
+	}
+}
+
+func TestListDNSPolicies_CachedPerSite(t *testing.T) {
+	srv, counts := newTestServer(t, map[string]interface{}{
+		"/v1/sites/site1/dns/policies": map[string]interface{}{"data": []DNSPolicy{{ID: "d1", Domain: "a.example"}}},
+		"/v1/sites/site2/dns/policies": map[string]interface{}{"data": []DNSPolicy{{ID: "d2", Domain: "b.example"}}},
+	})
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "key", "site1", false)
+
+	for _, tc := range []struct{ site, want string }{
+		{"site1", "d1"}, {"site2", "d2"}, {"site1", "d1"}, {"site2", "d2"},
+	} {
+		policies, err := client.ListDNSPolicies(context.Background(), tc.site)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(policies) != 1 || policies[0].ID != tc.want {
+			t.Errorf("%s: expected [%s], got %+v", tc.site, tc.want, policies)
+		}
+	}
+
+	for _, site := range []string{"site1", "site2"} {
+		if n := counts["/v1/sites/"+site+"/dns/policies"].Load(); n != 1 {
+			t.Errorf("%s: expected 1 API call, got %d", site, n)
+		}
+	}
+}
+
+func TestListFirewallZones_NoCrossSiteBleed(t *testing.T) {
+	srv, counts := newTestServer(t, map[string]interface{}{
+		"/v1/sites/site1/firewall/zones": map[string]interface{}{"data": []FirewallZone{{ID: "z1", Name: "LAN"}}},
+		"/v1/sites/site2/firewall/zones": map[string]interface{}{"data": []FirewallZone{{ID: "z2", Name: "Branch LAN"}}},
+	})
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "key", "site1", false)
+	site2 := WithSite(context.Background(), Site{ID: "site2"})
+
+	// Concurrent lists of both sites must not share one in-flight call.
+	var wg sync.WaitGroup
+	results := make([][]FirewallZone, 2)
+	for i, ctx := range []context.Context{context.Background(), site2} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = client.ListFirewallZones(ctx)
+		}()
+	}
+	wg.Wait()
+
+	if len(results[0]) != 1 || results[0][0].ID != "z1" {
+		t.Errorf("site1: expected [z1], got %+v", results[0])
+	}
+	if len(results[1]) != 1 || results[1][0].ID != "z2" {
+		t.Errorf("site2: expected [z2], got %+v", results[1])
+	}
+
+	// Invalidating site2 leaves the cached zones of site1 alone.
+	client.CreateFirewallZone(site2, FirewallZone{Name: "IoT"})
+	client.ListFirewallZones(context.Background())
+	if n := counts["/v1/sites/site1/firewall/zones"].Load(); n != 1 {
+		t.Errorf("expected site1 zones to stay cached, got %d API calls", n)
+	}
+}
+
+func TestCacheTTL_ZeroDisablesCaching(t *testing.T) {
+	srv, counts := newTestServer(t, map[string]interface{}{
+		"/v1/sites/site1/networks": map[string]interface{}{"data": []Network{{ID: "n1", Name: "Default"}}},
+	})
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "key", "site1", false)
+	client.CacheTTL = 0
+
+	client.ListNetworks(context.Background())
+	client.ListNetworks(context.Background())
+
+	if n := counts["/v1/sites/site1/networks"].Load(); n != 2 {
+		t.Errorf("expected 2 API calls without caching, got %d", n)
+	}
+}
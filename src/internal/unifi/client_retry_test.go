@@ -0,0 +1,253 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fastRetry returns a retry policy with millisecond waits so tests stay quick.
+func fastRetry(maxRetries int) RetryPolicy {
+	return RetryPolicy{MaxRetries: maxRetries, MinWait: time.Millisecond, MaxWait: 5 * time.Millisecond}
+}
+
+func TestRetry_GETRecoversFromTransientErrors(t *testing.T) {
+	for _, status := range []int{429, 502, 503, 504} {
+		srv, mock := newMockServer(t)
+		mock.SetErrorTimes("GET", "/v1/sites/site-1/networks", status, 2)
+
+		client := NewClient(srv.URL, "test-key", "site-1", false)
+		client.Retry = fastRetry(3)
+
+		networks, err := client.ListNetworks(context.Background())
+		if err != nil {
+			t.Fatalf("status %d: expected success after retries, got: %v", status, err)
+		}
+		if len(networks) != 2 {
+			t.Errorf("status %d: expected 2 networks, got %d", status, len(networks))
+		}
+		if got := mock.GetCallCount("GET", "/v1/sites/site-1/networks"); got != 3 {
+			t.Errorf("status %d: expected 3 calls (2 failures + success), got %d", status, got)
+		}
+	}
+}
+
+func TestRetry_GivesUpAfterMaxRetries(t *testing.T) {
+	srv, mock := newMockServer(t)
+	mock.SetError("GET", "/v1/sites", 503)
+
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+	client.Retry = fastRetry(2)
+
+	_, err := client.ListSites(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 503 {
+		t.Fatalf("expected 503 APIError, got: %v", err)
+	}
+	if got := mock.GetCallCount("GET", "/v1/sites"); got != 3 {
+		t.Errorf("expected 3 calls (1 + 2 retries), got %d", got)
+	}
+}
+
+func TestRetry_NonTransientStatusNotRetried(t *testing.T) {
+	for _, status := range []int{400, 401, 404, 500} {
+		srv, mock := newMockServer(t)
+		mock.SetError("GET", "/v1/sites", status)
+
+		client := NewClient(srv.URL, "test-key", "site-1", false)
+		client.Retry = fastRetry(3)
+
+		if _, err := client.ListSites(context.Background()); err == nil {
+			t.Fatalf("status %d: expected error, got nil", status)
+		}
+		if got := mock.GetCallCount("GET", "/v1/sites"); got != 1 {
+			t.Errorf("status %d: expected 1 call, got %d", status, got)
+		}
+	}
+}
+
+func TestRetry_PUTReplaysBody(t *testing.T) {
+	srv, mock := newMockServer(t)
+	mock.mu.Lock()
+	mock.fwPolicies["site-1"] = []FirewallPolicy{{ID: "fw-1", Name: "Old Name"}}
+	mock.mu.Unlock()
+	mock.SetErrorTimes("PUT", "/v1/sites/site-1/firewall/policies/fw-1", 502, 1)
+
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+	client.Retry = fastRetry(3)
+
+	updated, err := client.UpdateFirewallPolicy(context.Background(), "fw-1", FirewallPolicy{Name: "New Name"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Name != "New Name" {
+		t.Errorf("expected replayed body to carry 'New Name', got %q", updated.Name)
+	}
+	if got := mock.GetCallCount("PUT", "/v1/sites/site-1/firewall/policies/fw-1"); got != 2 {
+		t.Errorf("expected 2 PUT calls, got %d", got)
+	}
+}
+
+func TestRetry_DELETERetried(t *testing.T) {
+	srv, mock := newMockServer(t)
+	mock.mu.Lock()
+	mock.fwPolicies["site-1"] = []FirewallPolicy{{ID: "fw-1", Name: "To Delete"}}
+	mock.mu.Unlock()
+	mock.SetErrorTimes("DELETE", "/v1/sites/site-1/firewall/policies/fw-1", 503, 1)
+
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+	client.Retry = fastRetry(3)
+
+	if err := client.DeleteFirewallPolicy(context.Background(), "fw-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mock.GetCallCount("DELETE", "/v1/sites/site-1/firewall/policies/fw-1"); got != 2 {
+		t.Errorf("expected 2 DELETE calls, got %d", got)
+	}
+}
+
+func TestRetry_POSTNotRetriedOnStatus(t *testing.T) {
+	srv, mock := newMockServer(t)
+	mock.SetErrorTimes("POST", "/v1/sites/site-1/firewall/policies", 503, 1)
+
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+	client.Retry = fastRetry(3)
+
+	if _, err := client.CreateFirewallPolicy(context.Background(), FirewallPolicy{Name: "Test"}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := mock.GetCallCount("POST", "/v1/sites/site-1/firewall/policies"); got != 1 {
+		t.Errorf("expected POST not to be retried, got %d calls", got)
+	}
+}
+
+func TestRetry_DisabledWithZeroRetries(t *testing.T) {
+	srv, mock := newMockServer(t)
+	mock.SetErrorTimes("GET", "/v1/sites", 503, 1)
+
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+	client.Retry = fastRetry(0)
+
+	if _, err := client.ListSites(context.Background()); err == nil {
+		t.Fatal("expected error with retries disabled, got nil")
+	}
+	if got := mock.GetCallCount("GET", "/v1/sites"); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(srv.URL, "key", "site-1", false)
+	client.Retry = RetryPolicy{MaxRetries: 1, MinWait: time.Millisecond, MaxWait: 5 * time.Second}
+
+	start := time.Now()
+	if _, err := client.ListSites(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait for Retry-After (1s), waited %s", elapsed)
+	}
+}
+
+func TestRetry_StopsWhenContextCancelled(t *testing.T) {
+	srv, mock := newMockServer(t)
+	mock.SetError("GET", "/v1/sites", 503)
+
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+	client.Retry = RetryPolicy{MaxRetries: 10, MinWait: time.Hour, MaxWait: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.ListSites(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+	if got := mock.GetCallCount("GET", "/v1/sites"); got != 1 {
+		t.Errorf("expected 1 call before cancellation, got %d", got)
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	refused := &url.Error{Op: "Post", URL: "https://unifi", Err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}
+	reset := &url.Error{Op: "Get", URL: "https://unifi", Err: io.ErrUnexpectedEOF}
+
+	tests := []struct {
+		name   string
+		method string
+		err    error
+		want   bool
+	}{
+		{"GET 503", http.MethodGet, &APIError{StatusCode: 503}, true},
+		{"GET 429", http.MethodGet, &APIError{StatusCode: 429}, true},
+		{"GET 404", http.MethodGet, &APIError{StatusCode: 404}, false},
+		{"GET transport error", http.MethodGet, reset, true},
+		{"PUT 502", http.MethodPut, &APIError{StatusCode: 502}, true},
+		{"DELETE 504", http.MethodDelete, &APIError{StatusCode: 504}, true},
+		{"POST 503", http.MethodPost, &APIError{StatusCode: 503}, false},
+		{"POST transport error", http.MethodPost, reset, false},
+		{"POST connection refused", http.MethodPost, refused, true},
+	}
+	for _, tt := range tests {
+		if got := shouldRetry(tt.method, tt.err); got != tt.want {
+			t.Errorf("%s: shouldRetry = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 5, MinWait: 100 * time.Millisecond, MaxWait: time.Second}
+
+	for attempt, full := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		full *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			got := p.backoff(attempt, 0)
+			if got < full/2 || got > full {
+				t.Fatalf("attempt %d: backoff %s outside [%s, %s]", attempt, got, full/2, full)
+			}
+		}
+	}
+
+	if got := p.backoff(0, 500*time.Millisecond); got != 500*time.Millisecond {
+		t.Errorf("expected Retry-After to be used as-is, got %s", got)
+	}
+	if got := p.backoff(0, time.Minute); got != time.Second {
+		t.Errorf("expected Retry-After to be capped at MaxWait, got %s", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("7"); got != 7*time.Second {
+		t.Errorf("expected 7s, got %s", got)
+	}
+	if got := parseRetryAfter(""); got != 0 {
+		t.Errorf("expected 0 for empty header, got %s", got)
+	}
+	if got := parseRetryAfter("soon"); got != 0 {
+		t.Errorf("expected 0 for invalid header, got %s", got)
+	}
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(date); got <= 0 || got > 10*time.Second {
+		t.Errorf("expected HTTP-date Retry-After within 10s, got %s", got)
+	}
+}
@@ -1,9 +1,13 @@
 package unifi
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 // --- Sites ---
@@ -12,7 +16,7 @@ func TestListSites_HappyPath(t *testing.T) {
 	srv, mock := newMockServer(t)
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
-	sites, err := client.ListSites()
+	sites, err := client.ListSites(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -40,7 +44,7 @@ func TestListSites_Empty(t *testing.T) {
 	mock.mu.Unlock()
 
 	client := NewClient(srv.URL, "test-key", "site-1", false)
-	sites, err := client.ListSites()
+	sites, err := client.ListSites(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -54,7 +58,7 @@ func TestListSites_ServerError(t *testing.T) {
 	mock.SetError("GET", "/v1/sites", 500)
 
 	client := NewClient(srv.URL, "test-key", "site-1", false)
-	_, err := client.ListSites()
+	_, err := client.ListSites(context.Background())
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -68,7 +72,7 @@ func TestListSites_MalformedJSON(t *testing.T) {
 	mock.SetMalformedResponse("GET", "/v1/sites")
 
 	client := NewClient(srv.URL, "test-key", "site-1", false)
-	_, err := client.ListSites()
+	_, err := client.ListSites(context.Background())
 	if err == nil {
 		t.Fatal("expected unmarshal error, got nil")
 	}
@@ -83,7 +87,7 @@ func TestListFirewallZones_HappyPath(t *testing.T) {
 	srv, _ := newMockServer(t)
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
-	zones, err := client.ListFirewallZones()
+	zones, err := client.ListFirewallZones(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -103,14 +107,14 @@ func TestListFirewallZones_ServerError(t *testing.T) {
 	mock.SetError("GET", "/v1/sites/site-1/firewall/zones", 500)
 
 	client := NewClient(srv.URL, "test-key", "site-1", false)
-	_, err := client.ListFirewallZones()
+	_, err := client.ListFirewallZones(context.Background())
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
 
 	// Verify cache was NOT populated — next call should try again.
 	mock.ClearError("GET", "/v1/sites/site-1/firewall/zones")
-	zones, err := client.ListFirewallZones()
+	zones, err := client.ListFirewallZones(context.Background())
 	if err != nil {
 		t.Fatalf("expected success after clearing error: %v", err)
 	}
@@ -119,13 +123,255 @@ func TestListFirewallZones_ServerError(t *testing.T) {
 	}
 }
 
+func TestFirewallZone_FullCRUDCycle(t *testing.T) {
+	srv, _ := newMockServer(t)
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+	ctx := context.Background()
+
+	// Populate the cache so we can check that mutations invalidate it.
+	if _, err := client.ListFirewallZones(ctx); err != nil {
+		t.Fatalf("ListFirewallZones: %v", err)
+	}
+
+	created, err := client.CreateFirewallZone(ctx, FirewallZone{Name: "IoT", NetworkIDs: []string{"net-2"}})
+	if err != nil {
+		t.Fatalf("CreateFirewallZone: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("expected created zone to have an ID")
+	}
+
+	got, err := client.GetFirewallZone(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetFirewallZone: %v", err)
+	}
+	if got.Name != "IoT" || got.Predefined() {
+		t.Errorf("unexpected zone: %+v", got)
+	}
+
+	if _, err := client.UpdateFirewallZone(ctx, created.ID, FirewallZone{Name: "IoT", NetworkIDs: []string{"net-1", "net-2"}}); err != nil {
+		t.Fatalf("UpdateFirewallZone: %v", err)
+	}
+	got, _ = client.GetFirewallZone(ctx, created.ID)
+	if len(got.NetworkIDs) != 2 {
+		t.Errorf("expected 2 networks after update, got %v", got.NetworkIDs)
+	}
+
+	if err := client.DeleteFirewallZone(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteFirewallZone: %v", err)
+	}
+	if _, err := client.GetFirewallZone(ctx, created.ID); !IsNotFound(err) {
+		t.Errorf("expected 404 after delete, got %v", err)
+	}
+}
+
+func TestFirewallZone_Predefined(t *testing.T) {
+	tests := []struct {
+		zone FirewallZone
+		want bool
+	}{
+		{FirewallZone{Name: "External"}, true},
+		{FirewallZone{Name: "Vpn"}, true},
+		{FirewallZone{Name: "IoT"}, false},
+		{FirewallZone{Name: "Internal", Metadata: &FirewallZoneMetadata{Origin: "USER_DEFINED"}}, false},
+		{FirewallZone{Name: "Cameras", Metadata: &FirewallZoneMetadata{Origin: "SYSTEM_DEFINED"}}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.zone.Predefined(); got != tt.want {
+			t.Errorf("Predefined(%q, %+v) = %v, want %v", tt.zone.Name, tt.zone.Metadata, got, tt.want)
+		}
+	}
+}
+
+// --- Traffic Matching Lists ---
+
+func TestTrafficMatchingList_FullCRUDCycle(t *testing.T) {
+	srv, _ := newMockServer(t)
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+	ctx := context.Background()
+
+	if _, err := client.ListTrafficMatchingLists(ctx); err != nil {
+		t.Fatalf("ListTrafficMatchingLists: %v", err)
+	}
+
+	created, err := client.CreateTrafficMatchingList(ctx, TrafficMatchingList{
+		Type:  "PORTS",
+		Name:  "Web",
+		Items: []TrafficMatchingListItem{{Type: "PORT_NUMBER", Value: 443}},
+	})
+	if err != nil {
+		t.Fatalf("CreateTrafficMatchingList: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("expected created list to have an ID")
+	}
+
+	// The create must have invalidated the (empty) cached list.
+	got, err := client.GetTrafficMatchingList(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetTrafficMatchingList: %v", err)
+	}
+	if got.Name != "Web" || len(got.Items) != 1 || got.Items[0].Value != float64(443) {
+		t.Errorf("unexpected list: %+v", got)
+	}
+
+	update := TrafficMatchingList{
+		Type: "PORTS",
+		Name: "Web",
+		Items: []TrafficMatchingListItem{
+			{Type: "PORT_NUMBER", Value: 443},
+			{Type: "PORT_NUMBER_RANGE", Start: 8000, Stop: 8080},
+		},
+	}
+	if _, err := client.UpdateTrafficMatchingList(ctx, created.ID, update); err != nil {
+		t.Fatalf("UpdateTrafficMatchingList: %v", err)
+	}
+	got, _ = client.GetTrafficMatchingList(ctx, created.ID)
+	if len(got.Items) != 2 {
+		t.Errorf("expected 2 items after update, got %+v", got.Items)
+	}
+
+	if err := client.DeleteTrafficMatchingList(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteTrafficMatchingList: %v", err)
+	}
+	if _, err := client.GetTrafficMatchingList(ctx, created.ID); !IsNotFound(err) {
+		t.Errorf("expected 404 after delete, got %v", err)
+	}
+}
+
+func TestIPAddressFilter_TrafficMatchingListJSON(t *testing.T) {
+	body, err := json.Marshal(IPAddressFilter{Type: "TRAFFIC_MATCHING_LIST", TrafficMatchingListID: "list-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"TRAFFIC_MATCHING_LIST","matchOpposite":false,"trafficMatchingListId":"list-1"}`
+	if string(body) != want {
+		t.Errorf("got %s, want %s", body, want)
+	}
+}
+
+func TestMACAddressFilterJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter MACAddressFilter
+		want   string
+	}{
+		{"string form", MACAddressFilter{Address: "aa:bb:cc:dd:ee:ff"}, `"aa:bb:cc:dd:ee:ff"`},
+		{"object form", MACAddressFilter{Type: "MAC_ADDRESSES", MatchOpposite: true, MACAddresses: []string{"aa:bb:cc:dd:ee:ff"}},
+			`{"type":"MAC_ADDRESSES","matchOpposite":true,"macAddresses":["aa:bb:cc:dd:ee:ff"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(TrafficFilter{Type: "MAC_ADDRESS", MACAddressFilter: &tt.filter})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := `{"type":"MAC_ADDRESS","macAddressFilter":` + tt.want + `}`
+			if string(body) != want {
+				t.Errorf("got %s, want %s", body, want)
+			}
+
+			var decoded TrafficFilter
+			if err := json.Unmarshal(body, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			got := decoded.MACAddressFilter
+			if got == nil || got.Address != tt.filter.Address || got.MatchOpposite != tt.filter.MatchOpposite || len(got.MACAddresses) != len(tt.filter.MACAddresses) {
+				t.Errorf("round trip: got %+v, want %+v", got, tt.filter)
+			}
+		})
+	}
+
+	var f TrafficFilter
+	if err := json.Unmarshal([]byte(`{"macAddressFilter": 42}`), &f); err == nil {
+		t.Error("expected an error for a numeric macAddressFilter")
+	}
+}
+
+// --- VPN ---
+
+func TestListVPNServersAndTunnels(t *testing.T) {
+	srv, mock := newMockServer(t)
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+	ctx := context.Background()
+
+	servers, err := client.ListVPNServers(ctx)
+	if err != nil {
+		t.Fatalf("ListVPNServers: %v", err)
+	}
+	if len(servers) != 1 || servers[0].Name != "Remote Access" || !servers[0].Enabled {
+		t.Errorf("unexpected servers: %+v", servers)
+	}
+
+	tunnels, err := client.ListSiteToSiteVPNTunnels(ctx)
+	if err != nil {
+		t.Fatalf("ListSiteToSiteVPNTunnels: %v", err)
+	}
+	if len(tunnels) != 1 || tunnels[0].ID != "s2s-1" || tunnels[0].Type != "IPSEC" {
+		t.Errorf("unexpected tunnels: %+v", tunnels)
+	}
+
+	// Served from the cache.
+	client.ListVPNServers(ctx)
+	if n := mock.GetCallCount("GET", "/v1/sites/site-1/vpn/servers"); n != 1 {
+		t.Errorf("expected one VPN server list call, got %d", n)
+	}
+}
+
+// --- DPI catalog ---
+
+func TestListDPICatalog_Cached(t *testing.T) {
+	srv, mock := newMockServer(t)
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		apps, err := client.ListDPIApplications(ctx)
+		if err != nil {
+			t.Fatalf("ListDPIApplications: %v", err)
+		}
+		if len(apps) != 2 || apps[0].Name != "BitTorrent" || apps[0].ID != 65539 {
+			t.Errorf("unexpected applications: %+v", apps)
+		}
+
+		categories, err := client.ListDPICategories(ctx)
+		if err != nil {
+			t.Fatalf("ListDPICategories: %v", err)
+		}
+		if len(categories) != 2 || categories[1].Name != "Social Media" {
+			t.Errorf("unexpected categories: %+v", categories)
+		}
+	}
+
+	if n := mock.GetCallCount("GET", "/v1/dpi/applications"); n != 1 {
+		t.Errorf("expected the application catalog to be fetched once, got %d", n)
+	}
+	if n := mock.GetCallCount("GET", "/v1/dpi/categories"); n != 1 {
+		t.Errorf("expected the category catalog to be fetched once, got %d", n)
+	}
+}
+
+func TestApplicationFilterJSON(t *testing.T) {
+	body, err := json.Marshal(TrafficFilter{
+		Type:              "APPLICATION",
+		ApplicationFilter: &ApplicationFilter{Type: "APPLICATION_CATEGORIES", ApplicationCategoryIDs: []int{24}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"APPLICATION","applicationFilter":{"type":"APPLICATION_CATEGORIES","applicationCategoryIds":[24]}}`
+	if string(body) != want {
+		t.Errorf("got %s, want %s", body, want)
+	}
+}
+
 // --- Networks ---
 
 func TestListNetworks_HappyPath(t *testing.T) {
 	srv, _ := newMockServer(t)
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
-	networks, err := client.ListNetworks()
+	networks, err := client.ListNetworks(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -140,6 +386,62 @@ func TestListNetworks_HappyPath(t *testing.T) {
 	}
 }
 
+func TestNetwork_FullCRUDCycle(t *testing.T) {
+	srv, mock := newMockServer(t)
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+	ctx := context.Background()
+
+	created, err := client.CreateNetwork(ctx, Network{
+		Name:       "IoT",
+		VlanID:     30,
+		Management: "GATEWAY",
+		Enabled:    true,
+		IPv4Configuration: &NetworkIPv4Configuration{
+			HostIPAddress: "10.0.30.1",
+			PrefixLength:  24,
+			DHCPConfiguration: &NetworkDHCPConfiguration{
+				Mode:             "SERVER",
+				IPAddressRange:   &NetworkIPRange{Start: "10.0.30.100", Stop: "10.0.30.200"},
+				LeaseTimeSeconds: 3600,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateNetwork: %v", err)
+	}
+
+	got, err := client.GetNetwork(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetNetwork: %v", err)
+	}
+	if got.VlanID != 30 || got.IPv4Configuration == nil || got.IPv4Configuration.DHCPConfiguration.IPAddressRange.Start != "10.0.30.100" {
+		t.Errorf("unexpected network: %+v", got)
+	}
+
+	// Populate the zone cache; updating a network must invalidate it.
+	client.ListFirewallZones(ctx)
+	got.Name = "IoT Devices"
+	if _, err := client.UpdateNetwork(ctx, created.ID, *got); err != nil {
+		t.Fatalf("UpdateNetwork: %v", err)
+	}
+	client.ListFirewallZones(ctx)
+	if n := mock.GetCallCount("GET", "/v1/sites/site-1/firewall/zones"); n != 2 {
+		t.Errorf("expected zone cache to be invalidated by network update, got %d zone calls", n)
+	}
+
+	got, _ = client.GetNetwork(ctx, created.ID)
+	if got.Name != "IoT Devices" {
+		t.Errorf("expected updated name, got %q", got.Name)
+	}
+
+	if err := client.DeleteNetwork(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteNetwork: %v", err)
+	}
+	if _, err := client.GetNetwork(ctx, created.ID); !IsNotFound(err) {
+		t.Errorf("expected 404 after delete, got %v", err)
+	}
+}
+
 // --- Firewall Policies ---
 
 func TestListFirewallPolicies_HappyPath(t *testing.T) {
@@ -153,7 +455,7 @@ func TestListFirewallPolicies_HappyPath(t *testing.T) {
 	mock.mu.Unlock()
 
 	client := NewClient(srv.URL, "test-key", "site-1", false)
-	policies, err := client.ListFirewallPolicies()
+	policies, err := client.ListFirewallPolicies(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -172,7 +474,7 @@ func TestCreateFirewallPolicy_HappyPath(t *testing.T) {
 		Action:  FirewallAction{Type: "ALLOW"},
 	}
 
-	created, err := client.CreateFirewallPolicy(policy)
+	created, err := client.CreateFirewallPolicy(context.Background(), policy)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -189,7 +491,7 @@ func TestCreateFirewallPolicy_ServerError(t *testing.T) {
 	mock.SetError("POST", "/v1/sites/site-1/firewall/policies", 400)
 
 	client := NewClient(srv.URL, "test-key", "site-1", false)
-	_, err := client.CreateFirewallPolicy(FirewallPolicy{Name: "Test"})
+	_, err := client.CreateFirewallPolicy(context.Background(), FirewallPolicy{Name: "Test"})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -212,7 +514,7 @@ func TestGetFirewallPolicy_FromCache(t *testing.T) {
 
 	// Get three policies — should only need 1 list call.
 	for _, id := range []string{"fw-1", "fw-2", "fw-3"} {
-		p, err := client.GetFirewallPolicy(id)
+		p, err := client.GetFirewallPolicy(context.Background(), id)
 		if err != nil {
 			t.Fatalf("GetFirewallPolicy(%s): %v", id, err)
 		}
@@ -239,7 +541,7 @@ func TestGetFirewallPolicy_FallbackToDirectGET(t *testing.T) {
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
 	// Populate cache with empty-ish list
-	client.ListFirewallPolicies()
+	client.ListFirewallPolicies(context.Background())
 
 	// Now add a policy that isn't in the cache
 	mock.mu.Lock()
@@ -248,9 +550,9 @@ func TestGetFirewallPolicy_FallbackToDirectGET(t *testing.T) {
 	mock.mu.Unlock()
 
 	// Invalidate cache to force refetch
-	client.invalidateFWPolicyCache()
+	client.invalidateFWPolicyCache("site-1")
 
-	p, err := client.GetFirewallPolicy("fw-new")
+	p, err := client.GetFirewallPolicy(context.Background(), "fw-new")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -263,7 +565,7 @@ func TestGetFirewallPolicy_NotFound(t *testing.T) {
 	srv, _ := newMockServer(t)
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
-	_, err := client.GetFirewallPolicy("nonexistent")
+	_, err := client.GetFirewallPolicy(context.Background(), "nonexistent")
 	if err == nil {
 		t.Fatal("expected error for nonexistent policy, got nil")
 	}
@@ -282,7 +584,7 @@ func TestUpdateFirewallPolicy_HappyPath(t *testing.T) {
 
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
-	updated, err := client.UpdateFirewallPolicy("fw-1", FirewallPolicy{
+	updated, err := client.UpdateFirewallPolicy(context.Background(), "fw-1", FirewallPolicy{
 		Name:    "New Name",
 		Enabled: false,
 	})
@@ -308,14 +610,14 @@ func TestDeleteFirewallPolicy_HappyPath(t *testing.T) {
 
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
-	err := client.DeleteFirewallPolicy("fw-1")
+	err := client.DeleteFirewallPolicy(context.Background(), "fw-1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
 	// Verify it's gone
 	client.InvalidateCache()
-	policies, _ := client.ListFirewallPolicies()
+	policies, _ := client.ListFirewallPolicies(context.Background())
 	if len(policies) != 1 {
 		t.Fatalf("expected 1 policy remaining, got %d", len(policies))
 	}
@@ -328,12 +630,78 @@ func TestDeleteFirewallPolicy_NotFound(t *testing.T) {
 	srv, _ := newMockServer(t)
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
-	err := client.DeleteFirewallPolicy("nonexistent")
+	err := client.DeleteFirewallPolicy(context.Background(), "nonexistent")
 	if err == nil {
 		t.Fatal("expected error for nonexistent policy, got nil")
 	}
 }
 
+func seedOrderingPolicies(mock *mockUnifiAPI) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	lan := FirewallSourceDest{ZoneID: "zone-lan"}
+	wan := FirewallSourceDest{ZoneID: "zone-wan"}
+	mock.fwPolicies["site-1"] = []FirewallPolicy{
+		{ID: "fw-1", Name: "Allow Web", Source: lan, Destination: wan},
+		{ID: "fw-2", Name: "Block Telnet", Source: lan, Destination: wan},
+		{ID: "fw-3", Name: "Other Pair", Source: wan, Destination: lan},
+	}
+}
+
+func TestGetFirewallPolicyOrdering_HappyPath(t *testing.T) {
+	srv, mock := newMockServer(t)
+	seedOrderingPolicies(mock)
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+
+	ordering, err := client.GetFirewallPolicyOrdering(context.Background(), "zone-lan", "zone-wan")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ordering.BeforeSystemDefined) != 2 || ordering.BeforeSystemDefined[0] != "fw-1" || ordering.BeforeSystemDefined[1] != "fw-2" {
+		t.Errorf("expected [fw-1 fw-2], got %v", ordering.BeforeSystemDefined)
+	}
+}
+
+func TestUpdateFirewallPolicyOrdering_HappyPath(t *testing.T) {
+	srv, mock := newMockServer(t)
+	seedOrderingPolicies(mock)
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+
+	_, err := client.UpdateFirewallPolicyOrdering(context.Background(), "zone-lan", "zone-wan", FirewallPolicyOrdering{
+		BeforeSystemDefined: []string{"fw-2"},
+		AfterSystemDefined:  []string{"fw-1"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ordering, err := client.GetFirewallPolicyOrdering(context.Background(), "zone-lan", "zone-wan")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ordering.BeforeSystemDefined) != 1 || ordering.BeforeSystemDefined[0] != "fw-2" {
+		t.Errorf("expected before [fw-2], got %v", ordering.BeforeSystemDefined)
+	}
+	if len(ordering.AfterSystemDefined) != 1 || ordering.AfterSystemDefined[0] != "fw-1" {
+		t.Errorf("expected after [fw-1], got %v", ordering.AfterSystemDefined)
+	}
+}
+
+func TestUpdateFirewallPolicyOrdering_Rejected(t *testing.T) {
+	srv, mock := newMockServer(t)
+	seedOrderingPolicies(mock)
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+
+	// fw-3 belongs to a different zone pair.
+	_, err := client.UpdateFirewallPolicyOrdering(context.Background(), "zone-lan", "zone-wan", FirewallPolicyOrdering{
+		BeforeSystemDefined: []string{"fw-3", "fw-1", "fw-2"},
+	})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 APIError, got %v", err)
+	}
+}
+
 // --- DNS Policies ---
 
 func TestListDNSPolicies_HappyPath(t *testing.T) {
@@ -345,7 +713,7 @@ func TestListDNSPolicies_HappyPath(t *testing.T) {
 	mock.mu.Unlock()
 
 	client := NewClient(srv.URL, "test-key", "site-1", false)
-	policies, err := client.ListDNSPolicies("site-1")
+	policies, err := client.ListDNSPolicies(context.Background(), "site-1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -361,7 +729,7 @@ func TestCreateDNSPolicy_HappyPath(t *testing.T) {
 	srv, _ := newMockServer(t)
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
-	created, err := client.CreateDNSPolicy("site-1", DNSPolicy{
+	created, err := client.CreateDNSPolicy(context.Background(), "site-1", DNSPolicy{
 		Type:        "A_RECORD",
 		Domain:      "test.com",
 		Enabled:     true,
@@ -390,7 +758,7 @@ func TestGetDNSPolicy_FromCache(t *testing.T) {
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
 	for _, id := range []string{"dns-1", "dns-2"} {
-		p, err := client.GetDNSPolicy("site-1", id)
+		p, err := client.GetDNSPolicy(context.Background(), "site-1", id)
 		if err != nil {
 			t.Fatalf("GetDNSPolicy(%s): %v", id, err)
 		}
@@ -416,15 +784,15 @@ func TestGetDNSPolicy_FallbackToDirectGET(t *testing.T) {
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
 	// Populate cache, then add a new item
-	client.ListDNSPolicies("site-1")
+	client.ListDNSPolicies(context.Background(), "site-1")
 	mock.mu.Lock()
 	mock.dnsPolicies["site-1"] = append(mock.dnsPolicies["site-1"],
 		DNSPolicy{ID: "dns-new", Domain: "new.com"})
 	mock.mu.Unlock()
 
 	// Invalidate and get
-	client.invalidateDNSPolicyCache()
-	p, err := client.GetDNSPolicy("site-1", "dns-new")
+	client.invalidateDNSPolicyCache("site-1")
+	p, err := client.GetDNSPolicy(context.Background(), "site-1", "dns-new")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -443,7 +811,7 @@ func TestUpdateDNSPolicy_HappyPath(t *testing.T) {
 
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
-	updated, err := client.UpdateDNSPolicy("site-1", "dns-1", DNSPolicy{
+	updated, err := client.UpdateDNSPolicy(context.Background(), "site-1", "dns-1", DNSPolicy{
 		Domain: "new.com",
 		Type:   "A_RECORD",
 	})
@@ -464,14 +832,14 @@ func TestDeleteDNSPolicy_HappyPath(t *testing.T) {
 	mock.mu.Unlock()
 
 	client := NewClient(srv.URL, "test-key", "site-1", false)
-	err := client.DeleteDNSPolicy("site-1", "dns-1")
+	err := client.DeleteDNSPolicy(context.Background(), "site-1", "dns-1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
 	// Verify gone
 	client.InvalidateCache()
-	policies, _ := client.ListDNSPolicies("site-1")
+	policies, _ := client.ListDNSPolicies(context.Background(), "site-1")
 	if len(policies) != 0 {
 		t.Errorf("expected 0 policies after delete, got %d", len(policies))
 	}
@@ -489,7 +857,7 @@ func TestDoRequest_SetsHeaders(t *testing.T) {
 	t.Cleanup(srv.Close)
 
 	client := NewClient(srv.URL, "my-api-key", "site-1", false)
-	client.ListSites()
+	client.ListSites(context.Background())
 
 	if got := capturedHeaders.Get("X-API-Key"); got != "my-api-key" {
 		t.Errorf("expected X-API-Key 'my-api-key', got %q", got)
@@ -504,7 +872,7 @@ func TestDoRequest_Unauthorized(t *testing.T) {
 	mock.SetError("GET", "/v1/sites", 401)
 
 	client := NewClient(srv.URL, "bad-key", "site-1", false)
-	_, err := client.ListSites()
+	_, err := client.ListSites(context.Background())
 	if err == nil {
 		t.Fatal("expected error for 401, got nil")
 	}
@@ -519,12 +887,164 @@ func TestDoRequest_NetworkError(t *testing.T) {
 	srv.Close()
 
 	client := NewClient(srv.URL, "key", "site-1", false)
-	_, err := client.ListSites()
+	client.Retry = fastRetry(1)
+	_, err := client.ListSites(context.Background())
 	if err == nil {
 		t.Fatal("expected network error, got nil")
 	}
 }
 
+func TestDoRequest_APIErrorFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"statusCode":400,"statusName":"BAD_REQUEST","code":"api.err.InvalidPayload","message":"zoneId is required"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(srv.URL, "key", "site-1", false)
+	_, err := client.ListSites(context.Background())
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", apiErr.StatusCode)
+	}
+	if apiErr.Code != "api.err.InvalidPayload" {
+		t.Errorf("expected code 'api.err.InvalidPayload', got %q", apiErr.Code)
+	}
+	if apiErr.Message != "zoneId is required" {
+		t.Errorf("expected message 'zoneId is required', got %q", apiErr.Message)
+	}
+	if !contains(apiErr.Body, "BAD_REQUEST") {
+		t.Errorf("expected raw body to be kept, got %q", apiErr.Body)
+	}
+}
+
+func TestIsNotFound_404(t *testing.T) {
+	srv, _ := newMockServer(t)
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+
+	_, err := client.GetFirewallPolicy(context.Background(), "nonexistent")
+	if !IsNotFound(err) {
+		t.Errorf("expected IsNotFound for 404, got: %v", err)
+	}
+}
+
+func TestIsNotFound_TransientErrors(t *testing.T) {
+	for _, status := range []int{429, 500, 502, 503} {
+		srv, mock := newMockServer(t)
+		mock.SetError("GET", "/v1/sites/site-1/firewall/policies", status)
+
+		client := NewClient(srv.URL, "test-key", "site-1", false)
+		client.Retry = fastRetry(1)
+		_, err := client.GetFirewallPolicy(context.Background(), "fw-1")
+		if err == nil {
+			t.Fatalf("status %d: expected error, got nil", status)
+		}
+		if IsNotFound(err) {
+			t.Errorf("status %d: must not be reported as not found", status)
+		}
+	}
+}
+
+func TestIsNotFound_NetworkError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close()
+
+	client := NewClient(srv.URL, "key", "site-1", false)
+	client.Retry = fastRetry(1)
+	_, err := client.GetFirewallPolicy(context.Background(), "fw-1")
+	if err == nil {
+		t.Fatal("expected network error, got nil")
+	}
+	if IsNotFound(err) {
+		t.Error("network errors must not be reported as not found")
+	}
+}
+
+func TestGetClient_NotFoundIsTyped(t *testing.T) {
+	srv, _ := newMockServer(t)
+	client := newClientWithSiteRef(srv.URL)
+
+	_, err := client.GetClient(context.Background(), "site-1", "nonexistent")
+	if !IsNotFound(err) {
+		t.Errorf("expected IsNotFound for unknown client, got: %v", err)
+	}
+}
+
+// --- Context ---
+
+// newBlockingServer returns a server whose handler blocks until the test ends
+// or the client goes away, to exercise cancellation of in-flight requests.
+func newBlockingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	t.Cleanup(func() {
+		close(done)
+		srv.Close()
+	})
+	return srv
+}
+
+func TestContext_CancelsInFlightRequest(t *testing.T) {
+	srv := newBlockingServer(t)
+	client := NewClient(srv.URL, "key", "site-1", false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetFirewallPolicy(ctx, "fw-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("request was not cancelled promptly (took %s)", elapsed)
+	}
+}
+
+func TestContext_AlreadyCancelled(t *testing.T) {
+	srv, mock := newMockServer(t)
+	client := NewClient(srv.URL, "test-key", "site-1", false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.CreateFirewallPolicy(ctx, FirewallPolicy{Name: "Test"}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	if mock.GetCallCount("POST", "/v1/sites/site-1/firewall/policies") != 0 {
+		t.Error("expected no request to reach the server")
+	}
+}
+
+func TestContext_SharedListWaiterCancelled(t *testing.T) {
+	srv := newBlockingServer(t)
+	client := NewClient(srv.URL, "key", "site-1", false)
+
+	// The leader holds the shared list call open.
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	defer cancelLeader()
+	go client.ListNetworks(leaderCtx)
+	time.Sleep(20 * time.Millisecond)
+
+	// A waiter with a short deadline must return on its own deadline instead
+	// of blocking on the leader's call.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.ListNetworks(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+}
+
 // --- Full CRUD integration ---
 
 func TestFirewallPolicy_FullCRUDCycle(t *testing.T) {
@@ -532,7 +1052,7 @@ func TestFirewallPolicy_FullCRUDCycle(t *testing.T) {
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
 	// Create
-	created, err := client.CreateFirewallPolicy(FirewallPolicy{
+	created, err := client.CreateFirewallPolicy(context.Background(), FirewallPolicy{
 		Name:    "Integration Test",
 		Enabled: true,
 		Action:  FirewallAction{Type: "ALLOW"},
@@ -542,7 +1062,7 @@ func TestFirewallPolicy_FullCRUDCycle(t *testing.T) {
 	}
 
 	// Read
-	got, err := client.GetFirewallPolicy(created.ID)
+	got, err := client.GetFirewallPolicy(context.Background(), created.ID)
 	if err != nil {
 		t.Fatalf("read: %v", err)
 	}
@@ -551,7 +1071,7 @@ func TestFirewallPolicy_FullCRUDCycle(t *testing.T) {
 	}
 
 	// Update
-	updated, err := client.UpdateFirewallPolicy(created.ID, FirewallPolicy{
+	updated, err := client.UpdateFirewallPolicy(context.Background(), created.ID, FirewallPolicy{
 		Name:    "Updated Name",
 		Enabled: false,
 		Action:  FirewallAction{Type: "BLOCK"},
@@ -564,14 +1084,14 @@ func TestFirewallPolicy_FullCRUDCycle(t *testing.T) {
 	}
 
 	// Delete
-	err = client.DeleteFirewallPolicy(created.ID)
+	err = client.DeleteFirewallPolicy(context.Background(), created.ID)
 	if err != nil {
 		t.Fatalf("delete: %v", err)
 	}
 
 	// Verify deleted
 	client.InvalidateCache()
-	_, err = client.GetFirewallPolicy(created.ID)
+	_, err = client.GetFirewallPolicy(context.Background(), created.ID)
 	if err == nil {
 		t.Error("expected error after delete, got nil")
 	}
@@ -582,7 +1102,7 @@ func TestDNSPolicy_FullCRUDCycle(t *testing.T) {
 	client := NewClient(srv.URL, "test-key", "site-1", false)
 
 	// Create
-	created, err := client.CreateDNSPolicy("site-1", DNSPolicy{
+	created, err := client.CreateDNSPolicy(context.Background(), "site-1", DNSPolicy{
 		Type:        "A_RECORD",
 		Domain:      "crud-test.com",
 		Enabled:     true,
@@ -593,7 +1113,7 @@ func TestDNSPolicy_FullCRUDCycle(t *testing.T) {
 	}
 
 	// Read
-	got, err := client.GetDNSPolicy("site-1", created.ID)
+	got, err := client.GetDNSPolicy(context.Background(), "site-1", created.ID)
 	if err != nil {
 		t.Fatalf("read: %v", err)
 	}
@@ -602,7 +1122,7 @@ func TestDNSPolicy_FullCRUDCycle(t *testing.T) {
 	}
 
 	// Update
-	updated, err := client.UpdateDNSPolicy("site-1", created.ID, DNSPolicy{
+	updated, err := client.UpdateDNSPolicy(context.Background(), "site-1", created.ID, DNSPolicy{
 		Type:   "A_RECORD",
 		Domain: "updated.com",
 	})
@@ -614,7 +1134,7 @@ func TestDNSPolicy_FullCRUDCycle(t *testing.T) {
 	}
 
 	// Delete
-	err = client.DeleteDNSPolicy("site-1", created.ID)
+	err = client.DeleteDNSPolicy(context.Background(), "site-1", created.ID)
 	if err != nil {
 		t.Fatalf("delete: %v", err)
 	}
@@ -632,7 +1152,7 @@ func TestListClients_HappyPath(t *testing.T) {
 	srv, mock := newMockServer(t)
 	client := newClientWithSiteRef(srv.URL)
 
-	clients, err := client.ListClients("site-1")
+	clients, err := client.ListClients(context.Background(), "site-1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -652,7 +1172,7 @@ func TestListClients_ServerError(t *testing.T) {
 	mock.SetError("GET", "/api/s/default/rest/user", 500)
 
 	client := newClientWithSiteRef(srv.URL)
-	_, err := client.ListClients("site-1")
+	_, err := client.ListClients(context.Background(), "site-1")
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -663,7 +1183,7 @@ func TestListClients_MalformedJSON(t *testing.T) {
 	mock.SetMalformedResponse("GET", "/api/s/default/rest/user")
 
 	client := newClientWithSiteRef(srv.URL)
-	_, err := client.ListClients("site-1")
+	_, err := client.ListClients(context.Background(), "site-1")
 	if err == nil {
 		t.Fatal("expected unmarshal error, got nil")
 	}
@@ -676,7 +1196,7 @@ func TestGetClient_HappyPath(t *testing.T) {
 	srv, _ := newMockServer(t)
 	client := newClientWithSiteRef(srv.URL)
 
-	dev, err := client.GetClient("site-1", "client-1")
+	dev, err := client.GetClient(context.Background(), "site-1", "client-1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -692,7 +1212,7 @@ func TestGetClient_NotFound(t *testing.T) {
 	srv, _ := newMockServer(t)
 	client := newClientWithSiteRef(srv.URL)
 
-	_, err := client.GetClient("site-1", "nonexistent")
+	_, err := client.GetClient(context.Background(), "site-1", "nonexistent")
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -703,7 +1223,7 @@ func TestGetClient_MalformedJSON(t *testing.T) {
 	mock.SetMalformedResponse("GET", "/api/s/default/rest/user/client-1")
 
 	client := newClientWithSiteRef(srv.URL)
-	_, err := client.GetClient("site-1", "client-1")
+	_, err := client.GetClient(context.Background(), "site-1", "client-1")
 	if err == nil {
 		t.Fatal("expected unmarshal error, got nil")
 	}
@@ -713,7 +1233,7 @@ func TestSetClientFixedIP_HappyPath(t *testing.T) {
 	srv, mock := newMockServer(t)
 	client := newClientWithSiteRef(srv.URL)
 
-	dev, err := client.SetClientFixedIP("site-1", "client-1", "net-1", "192.168.1.100", "server1")
+	dev, err := client.SetClientFixedIP(context.Background(), "site-1", "client-1", "net-1", "192.168.1.100", "server1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -740,7 +1260,7 @@ func TestSetClientFixedIP_NotFound(t *testing.T) {
 	srv, _ := newMockServer(t)
 	client := newClientWithSiteRef(srv.URL)
 
-	_, err := client.SetClientFixedIP("site-1", "nonexistent", "net-1", "192.168.1.100", "test")
+	_, err := client.SetClientFixedIP(context.Background(), "site-1", "nonexistent", "net-1", "192.168.1.100", "test")
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -751,13 +1271,13 @@ func TestUnsetClientFixedIP_HappyPath(t *testing.T) {
 	client := newClientWithSiteRef(srv.URL)
 
 	// First set a fixed IP
-	_, err := client.SetClientFixedIP("site-1", "client-1", "net-1", "192.168.1.100", "server1")
+	_, err := client.SetClientFixedIP(context.Background(), "site-1", "client-1", "net-1", "192.168.1.100", "server1")
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
 	// Then unset it
-	err = client.UnsetClientFixedIP("site-1", "client-1")
+	err = client.UnsetClientFixedIP(context.Background(), "site-1", "client-1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -775,7 +1295,7 @@ func TestUnsetClientFixedIP_NotFound(t *testing.T) {
 	srv, _ := newMockServer(t)
 	client := newClientWithSiteRef(srv.URL)
 
-	err := client.UnsetClientFixedIP("site-1", "nonexistent")
+	err := client.UnsetClientFixedIP(context.Background(), "site-1", "nonexistent")
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -800,13 +1320,13 @@ func containsStr(s, sub string) bool {
 func TestNewClientWithCredentials_HappyPath(t *testing.T) {
 	srv, _ := newMockServer(t)
 
-	client, err := NewClientWithCredentials(srv.URL, "admin", "password", "site-1", false)
+	client, err := NewClientWithCredentials(context.Background(), srv.URL, "admin", "password", "site-1", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
 	// Verify the client can make API calls with the session cookie
-	sites, err := client.ListSites()
+	sites, err := client.ListSites(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error listing sites: %v", err)
 	}
@@ -818,7 +1338,7 @@ func TestNewClientWithCredentials_HappyPath(t *testing.T) {
 func TestNewClientWithCredentials_BadPassword(t *testing.T) {
 	srv, _ := newMockServer(t)
 
-	_, err := NewClientWithCredentials(srv.URL, "admin", "wrong", "site-1", false)
+	_, err := NewClientWithCredentials(context.Background(), srv.URL, "admin", "wrong", "site-1", false)
 	if err == nil {
 		t.Fatal("expected error for bad credentials, got nil")
 	}
@@ -827,7 +1347,7 @@ func TestNewClientWithCredentials_BadPassword(t *testing.T) {
 func TestNewClientWithCredentials_CSRFToken(t *testing.T) {
 	srv, mock := newMockServer(t)
 
-	client, err := NewClientWithCredentials(srv.URL, "admin", "password", "site-1", false)
+	client, err := NewClientWithCredentials(context.Background(), srv.URL, "admin", "password", "site-1", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -854,12 +1374,12 @@ func TestNewClientWithCredentials_NoAPIKeyHeader(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	client, err := NewClientWithCredentials(srv.URL, "admin", "password", "site-1", false)
+	client, err := NewClientWithCredentials(context.Background(), srv.URL, "admin", "password", "site-1", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	client.ListSites()
+	client.ListSites(context.Background())
 
 	if capturedHeaders.Get("X-API-Key") != "" {
 		t.Error("cookie auth should not send X-API-Key header")
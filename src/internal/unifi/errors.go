@@ -0,0 +1,69 @@
+package unifi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// APIError is returned by the client whenever the controller answers with a
+// non-2xx status. It keeps the raw body so callers can surface it verbatim.
+type APIError struct {
+	StatusCode int
+	Code       string // API error code, e.g. "api.err.NotFound", when the body carries one
+	Message    string
+	Body       string
+
+	retryAfter time.Duration // parsed Retry-After header, if any
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" && e.Message != "" {
+		return fmt.Sprintf("api error: status %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("api error: status %d, body: %s", e.StatusCode, e.Body)
+}
+
+// newAPIError builds an APIError from a failed response, extracting the error
+// code and message from whichever envelope the endpoint uses: the integration
+// API ({"code","message"}), the legacy REST API ({"meta":{"msg"}}) or a bare
+// {"error","message"} object.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Body: string(body)}
+
+	var envelope struct {
+		Code    string          `json:"code"`
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+		Meta    struct {
+			Msg string `json:"msg"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return apiErr
+	}
+
+	apiErr.Code = envelope.Code
+	if apiErr.Code == "" {
+		var code string
+		if json.Unmarshal(envelope.Error, &code) == nil {
+			apiErr.Code = code
+		}
+	}
+	if apiErr.Code == "" {
+		apiErr.Code = envelope.Meta.Msg
+	}
+	apiErr.Message = envelope.Message
+
+	return apiErr
+}
+
+// IsNotFound reports whether err is an API error for a resource that does not
+// exist. Transport failures and other statuses (timeouts, 5xx, 429) are not
+// "not found" and must not cause a resource to be dropped from state.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
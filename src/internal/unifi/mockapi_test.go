@@ -18,12 +18,18 @@ import (
 type mockUnifiAPI struct {
 	mu sync.Mutex
 
-	sites      []Site
-	zones      map[string][]FirewallZone   // keyed by siteID
-	networks   map[string][]Network        // keyed by siteID
-	fwPolicies  map[string][]FirewallPolicy // keyed by siteID
-	dnsPolicies map[string][]DNSPolicy      // keyed by siteID
-	clients     map[string][]ClientDevice   // keyed by siteID
+	sites         []Site
+	zones         map[string][]FirewallZone         // keyed by siteID
+	networks      map[string][]Network              // keyed by siteID
+	fwPolicies    map[string][]FirewallPolicy       // keyed by siteID
+	fwOrdering    map[string]FirewallPolicyOrdering // keyed by "siteID/srcZone/dstZone"
+	dnsPolicies   map[string][]DNSPolicy            // keyed by siteID
+	clients       map[string][]ClientDevice         // keyed by siteID
+	trafficLists  map[string][]TrafficMatchingList  // keyed by siteID
+	vpnServers    map[string][]VPNServer            // keyed by siteID
+	vpnTunnels    map[string][]SiteToSiteVPNTunnel  // keyed by siteID
+	dpiApps       []DPIApplication
+	dpiCategories []DPICategory
 
 	nextID int
 
@@ -32,6 +38,24 @@ type mockUnifiAPI struct {
 
 	// errorOverrides forces a specific HTTP status for matching "METHOD /path-prefix".
 	errorOverrides map[string]int
+
+	// errorRemaining limits how many more times an override fires before it is
+	// cleared. Overrides without an entry fire until ClearError is called.
+	errorRemaining map[string]int
+
+	// enforceSession makes cookie-authenticated requests fail with 401 unless
+	// they carry the TOKEN cookie issued by the most recent login.
+	enforceSession bool
+	sessionToken   string
+	sessionSeq     int
+
+	// updatedCSRFToken, when set, is announced on every response via
+	// X-Updated-CSRF-Token, as UniFi OS does when it rotates the token.
+	updatedCSRFToken string
+
+	// legacyLoginMissing makes /api/login return 404, as on UniFi OS consoles
+	// that only serve /api/auth/login.
+	legacyLoginMissing bool
 }
 
 // newMockServer creates a mock API with sensible defaults and returns the
@@ -54,8 +78,24 @@ func newMockServer(t *testing.T) (*httptest.Server, *mockUnifiAPI) {
 				{ID: "net-2", Name: "Guest", VlanID: 100, Management: "GATEWAY"},
 			},
 		},
-		fwPolicies:  map[string][]FirewallPolicy{},
-		dnsPolicies: map[string][]DNSPolicy{},
+		fwPolicies:   map[string][]FirewallPolicy{},
+		fwOrdering:   map[string]FirewallPolicyOrdering{},
+		dnsPolicies:  map[string][]DNSPolicy{},
+		trafficLists: map[string][]TrafficMatchingList{},
+		vpnServers: map[string][]VPNServer{
+			"site-1": {{ID: "vpn-1", Name: "Remote Access", Type: "WIREGUARD", Enabled: true}},
+		},
+		vpnTunnels: map[string][]SiteToSiteVPNTunnel{
+			"site-1": {{ID: "s2s-1", Name: "Branch Office", Type: "IPSEC"}},
+		},
+		dpiApps: []DPIApplication{
+			{ID: 65539, Name: "BitTorrent"},
+			{ID: 262256, Name: "Facebook"},
+		},
+		dpiCategories: []DPICategory{
+			{ID: 4, Name: "Peer-to-Peer"},
+			{ID: 24, Name: "Social Media"},
+		},
 		clients: map[string][]ClientDevice{
 			"site-1": {
 				{ID: "client-1", MAC: "00:11:22:33:44:55", Name: "server1"},
@@ -64,6 +104,7 @@ func newMockServer(t *testing.T) (*httptest.Server, *mockUnifiAPI) {
 		},
 		callCounts:     map[string]*atomic.Int32{},
 		errorOverrides: map[string]int{},
+		errorRemaining: map[string]int{},
 		nextID:         100,
 	}
 
@@ -72,6 +113,19 @@ func newMockServer(t *testing.T) (*httptest.Server, *mockUnifiAPI) {
 	return srv, m
 }
 
+// siteIDForReference returns the ID of the site with the given internal
+// reference, or the reference itself when no site has it.
+func (m *mockUnifiAPI) siteIDForReference(ref string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, site := range m.sites {
+		if site.InternalReference == ref {
+			return site.ID
+		}
+	}
+	return ref
+}
+
 func (m *mockUnifiAPI) genID() string {
 	m.nextID++
 	return fmt.Sprintf("mock-%d", m.nextID)
@@ -107,11 +161,21 @@ func (m *mockUnifiAPI) SetError(method, pathPrefix string, statusCode int) {
 	m.errorOverrides[method+" "+pathPrefix] = statusCode
 }
 
+// SetErrorTimes is like SetError but the override clears itself after firing
+// the given number of times, so the following request succeeds.
+func (m *mockUnifiAPI) SetErrorTimes(method, pathPrefix string, statusCode, times int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errorOverrides[method+" "+pathPrefix] = statusCode
+	m.errorRemaining[method+" "+pathPrefix] = times
+}
+
 // ClearError removes an error override.
 func (m *mockUnifiAPI) ClearError(method, pathPrefix string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	delete(m.errorOverrides, method+" "+pathPrefix)
+	delete(m.errorRemaining, method+" "+pathPrefix)
 }
 
 // SetMalformedResponse makes the mock return invalid JSON for matching requests.
@@ -129,6 +193,14 @@ func (m *mockUnifiAPI) checkError(method, path string, w http.ResponseWriter) bo
 	for key, code := range m.errorOverrides {
 		parts := strings.SplitN(key, " ", 2)
 		if len(parts) == 2 && parts[0] == method && strings.HasPrefix(path, parts[1]) {
+			if remaining, ok := m.errorRemaining[key]; ok {
+				if remaining <= 1 {
+					delete(m.errorOverrides, key)
+					delete(m.errorRemaining, key)
+				} else {
+					m.errorRemaining[key] = remaining - 1
+				}
+			}
 			if code == 999 {
 				// Malformed response
 				w.WriteHeader(http.StatusOK)
@@ -143,19 +215,80 @@ func (m *mockUnifiAPI) checkError(method, path string, w http.ResponseWriter) bo
 	return false
 }
 
+// EnforceSession turns on session checking for cookie-authenticated requests.
+func (m *mockUnifiAPI) EnforceSession() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.enforceSession = true
+}
+
+// ExpireSession invalidates the current session so the next cookie request
+// gets a 401 until the client logs in again.
+func (m *mockUnifiAPI) ExpireSession() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessionToken = ""
+}
+
+// SetUpdatedCSRFToken makes every response announce token as the new CSRF token.
+func (m *mockUnifiAPI) SetUpdatedCSRFToken(token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updatedCSRFToken = token
+}
+
+// SetLegacyLoginMissing makes /api/login return 404.
+func (m *mockUnifiAPI) SetLegacyLoginMissing() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.legacyLoginMissing = true
+}
+
+// checkSession rejects cookie requests with a stale session when session
+// enforcement is on. It returns true if a 401 was written.
+func (m *mockUnifiAPI) checkSession(r *http.Request, w http.ResponseWriter) bool {
+	if r.Header.Get("X-API-Key") != "" {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.enforceSession {
+		return false
+	}
+	if cookie, err := r.Cookie("TOKEN"); err == nil && m.sessionToken != "" && cookie.Value == m.sessionToken {
+		return false
+	}
+	w.WriteHeader(http.StatusUnauthorized)
+	json.NewEncoder(w).Encode(map[string]interface{}{"meta": map[string]string{"rc": "error", "msg": "api.err.LoginRequired"}})
+	return true
+}
+
 func (m *mockUnifiAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	path := r.URL.Path
+	// UniFi OS serves the Network Application under /proxy/network.
+	path := strings.TrimPrefix(r.URL.Path, "/proxy/network")
+	path = strings.TrimPrefix(path, "/integration")
 	method := r.Method
 	m.trackCall(method, path)
 
 	w.Header().Set("Content-Type", "application/json")
+	m.mu.Lock()
+	if m.updatedCSRFToken != "" {
+		w.Header().Set("X-Updated-CSRF-Token", m.updatedCSRFToken)
+	}
+	legacyLoginMissing := m.legacyLoginMissing
+	m.mu.Unlock()
 
 	if m.checkError(method, path, w) {
 		return
 	}
 
-	// Route: POST /api/login (legacy cookie auth)
-	if path == "/api/login" && method == http.MethodPost {
+	if path == "/api/login" && legacyLoginMissing {
+		http.NotFound(w, r)
+		return
+	}
+
+	// Route: POST /api/login (legacy cookie auth) or /api/auth/login (UniFi OS)
+	if (path == "/api/login" || path == "/api/auth/login") && method == http.MethodPost {
 		body, _ := io.ReadAll(r.Body)
 		var creds struct {
 			Username string `json:"username"`
@@ -163,7 +296,12 @@ func (m *mockUnifiAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 		json.Unmarshal(body, &creds)
 		if creds.Username == "admin" && creds.Password == "password" {
-			http.SetCookie(w, &http.Cookie{Name: "TOKEN", Value: "mock-session-token", Path: "/"})
+			m.mu.Lock()
+			m.sessionSeq++
+			m.sessionToken = fmt.Sprintf("mock-session-token-%d", m.sessionSeq)
+			token := m.sessionToken
+			m.mu.Unlock()
+			http.SetCookie(w, &http.Cookie{Name: "TOKEN", Value: token, Path: "/"})
 			http.SetCookie(w, &http.Cookie{Name: "csrf_token", Value: "mock-csrf-token", Path: "/"})
 			w.Header().Set("X-CSRF-Token", "mock-csrf-token")
 			json.NewEncoder(w).Encode(map[string]interface{}{"meta": map[string]string{"rc": "ok"}, "data": []interface{}{}})
@@ -174,13 +312,17 @@ func (m *mockUnifiAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if m.checkSession(r, w) {
+		return
+	}
+
 	// Route: REST API /api/s/{site}/rest/user (legacy client CRUD)
 	if strings.HasPrefix(path, "/api/s/") {
 		restParts := strings.Split(strings.TrimPrefix(path, "/api/s/"), "/")
 		// /api/s/{siteRef}/rest/user or /api/s/{siteRef}/rest/user/{id}
 		if len(restParts) >= 3 && restParts[1] == "rest" && restParts[2] == "user" {
-			// Map siteRef to siteID (for mock, "default" -> "site-1")
-			siteID := "site-1"
+			// Map the site's internal reference ("default") to its ID
+			siteID := m.siteIDForReference(restParts[0])
 			if len(restParts) == 3 {
 				// Collection
 				m.handleRestClients(w, r, siteID)
@@ -201,6 +343,22 @@ func (m *mockUnifiAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Route: GET /v1/dpi/applications and /v1/dpi/categories
+	if path == "/v1/dpi/applications" && method == http.MethodGet {
+		m.mu.Lock()
+		apps := m.dpiApps
+		m.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": apps})
+		return
+	}
+	if path == "/v1/dpi/categories" && method == http.MethodGet {
+		m.mu.Lock()
+		categories := m.dpiCategories
+		m.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": categories})
+		return
+	}
+
 	// Parse /v1/sites/{siteId}/...
 	parts := strings.Split(strings.TrimPrefix(path, "/v1/sites/"), "/")
 	if len(parts) < 2 {
@@ -210,20 +368,53 @@ func (m *mockUnifiAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	siteID := parts[0]
 
 	// Route: firewall zones
-	if len(parts) == 3 && parts[1] == "firewall" && parts[2] == "zones" && method == http.MethodGet {
+	if len(parts) == 3 && parts[1] == "firewall" && parts[2] == "zones" {
+		m.handleZones(w, r, siteID)
+		return
+	}
+
+	// Route: firewall zones single item
+	if len(parts) == 4 && parts[1] == "firewall" && parts[2] == "zones" {
+		m.handleZone(w, r, siteID, parts[3])
+		return
+	}
+
+	// Route: VPN servers and site-to-site tunnels (read-only)
+	if len(parts) == 3 && parts[1] == "vpn" && method == http.MethodGet {
 		m.mu.Lock()
-		zones := m.zones[siteID]
-		m.mu.Unlock()
-		json.NewEncoder(w).Encode(map[string]interface{}{"data": zones})
+		defer m.mu.Unlock()
+		switch parts[2] {
+		case "servers":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": m.vpnServers[siteID]})
+		case "site-to-site-tunnels":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": m.vpnTunnels[siteID]})
+		default:
+			http.NotFound(w, r)
+		}
 		return
 	}
 
 	// Route: networks
-	if len(parts) == 2 && parts[1] == "networks" && method == http.MethodGet {
-		m.mu.Lock()
-		networks := m.networks[siteID]
-		m.mu.Unlock()
-		json.NewEncoder(w).Encode(map[string]interface{}{"data": networks})
+	if len(parts) == 2 && parts[1] == "networks" {
+		m.handleNetworks(w, r, siteID)
+		return
+	}
+
+	// Route: networks single item
+	if len(parts) == 3 && parts[1] == "networks" {
+		m.handleNetwork(w, r, siteID, parts[2])
+		return
+	}
+
+	// Route: traffic matching lists
+	if len(parts) == 2 && parts[1] == "traffic-matching-lists" {
+		m.handleTrafficLists(w, r, siteID)
+		return
+	}
+
+	// Route: traffic matching lists single item
+	if len(parts) == 3 && parts[1] == "traffic-matching-lists" {
+		m.handleTrafficList(w, r, siteID, parts[2])
 		return
 	}
 
@@ -233,6 +424,12 @@ func (m *mockUnifiAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Route: firewall policy ordering (must precede the single item route)
+	if len(parts) == 4 && parts[1] == "firewall" && parts[2] == "policies" && parts[3] == "ordering" {
+		m.handleFWOrdering(w, r, siteID)
+		return
+	}
+
 	// Route: firewall policies single item
 	if len(parts) == 4 && parts[1] == "firewall" && parts[2] == "policies" {
 		m.handleFWPolicy(w, r, siteID, parts[3])
@@ -277,6 +474,240 @@ func (m *mockUnifiAPI) handleFWPolicies(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+func (m *mockUnifiAPI) handleNetworks(w http.ResponseWriter, r *http.Request, siteID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": m.networks[siteID]})
+	case http.MethodPost:
+		body, _ := io.ReadAll(r.Body)
+		var network Network
+		json.Unmarshal(body, &network)
+		for _, existing := range m.networks[siteID] {
+			if existing.VlanID == network.VlanID {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "vlan already in use"})
+				return
+			}
+		}
+		network.ID = m.genID()
+		m.networks[siteID] = append(m.networks[siteID], network)
+		json.NewEncoder(w).Encode(network)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (m *mockUnifiAPI) handleNetwork(w http.ResponseWriter, r *http.Request, siteID, networkID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	networks := m.networks[siteID]
+	idx := -1
+	for i, n := range networks {
+		if n.ID == networkID {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "not found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(networks[idx])
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		var network Network
+		json.Unmarshal(body, &network)
+		network.ID = networkID
+		m.networks[siteID][idx] = network
+		json.NewEncoder(w).Encode(network)
+	case http.MethodDelete:
+		m.networks[siteID] = append(networks[:idx], networks[idx+1:]...)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (m *mockUnifiAPI) handleZones(w http.ResponseWriter, r *http.Request, siteID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": m.zones[siteID]})
+	case http.MethodPost:
+		body, _ := io.ReadAll(r.Body)
+		var zone FirewallZone
+		json.Unmarshal(body, &zone)
+		zone.ID = m.genID()
+		zone.Metadata = &FirewallZoneMetadata{Origin: "USER_DEFINED"}
+		m.zones[siteID] = append(m.zones[siteID], zone)
+		json.NewEncoder(w).Encode(zone)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (m *mockUnifiAPI) handleZone(w http.ResponseWriter, r *http.Request, siteID, zoneID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	zones := m.zones[siteID]
+	idx := -1
+	for i, z := range zones {
+		if z.ID == zoneID {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "not found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(zones[idx])
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		var zone FirewallZone
+		json.Unmarshal(body, &zone)
+		zone.ID = zoneID
+		zone.Metadata = zones[idx].Metadata
+		m.zones[siteID][idx] = zone
+		json.NewEncoder(w).Encode(zone)
+	case http.MethodDelete:
+		m.zones[siteID] = append(zones[:idx], zones[idx+1:]...)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (m *mockUnifiAPI) handleTrafficLists(w http.ResponseWriter, r *http.Request, siteID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": m.trafficLists[siteID]})
+	case http.MethodPost:
+		body, _ := io.ReadAll(r.Body)
+		var list TrafficMatchingList
+		json.Unmarshal(body, &list)
+		list.ID = m.genID()
+		m.trafficLists[siteID] = append(m.trafficLists[siteID], list)
+		json.NewEncoder(w).Encode(list)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (m *mockUnifiAPI) handleTrafficList(w http.ResponseWriter, r *http.Request, siteID, listID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lists := m.trafficLists[siteID]
+	idx := -1
+	for i, l := range lists {
+		if l.ID == listID {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "not found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(lists[idx])
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		var list TrafficMatchingList
+		json.Unmarshal(body, &list)
+		list.ID = listID
+		m.trafficLists[siteID][idx] = list
+		json.NewEncoder(w).Encode(list)
+	case http.MethodDelete:
+		m.trafficLists[siteID] = append(lists[:idx], lists[idx+1:]...)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleFWOrdering serves the per-zone-pair policy ordering. Without a stored
+// ordering, the pair's policies are returned in creation order.
+func (m *mockUnifiAPI) handleFWOrdering(w http.ResponseWriter, r *http.Request, siteID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	src := r.URL.Query().Get("sourceFirewallZoneId")
+	dst := r.URL.Query().Get("destinationFirewallZoneId")
+	if src == "" || dst == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "zone ids required"})
+		return
+	}
+	key := siteID + "/" + src + "/" + dst
+
+	pairIDs := map[string]bool{}
+	var derived []string
+	for _, p := range m.fwPolicies[siteID] {
+		if p.Source.ZoneID == src && p.Destination.ZoneID == dst {
+			pairIDs[p.ID] = true
+			derived = append(derived, p.ID)
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		ordering, ok := m.fwOrdering[key]
+		if !ok {
+			ordering = FirewallPolicyOrdering{BeforeSystemDefined: derived, AfterSystemDefined: []string{}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"orderedFirewallPolicyIds": ordering})
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			OrderedFirewallPolicyIDs FirewallPolicyOrdering `json:"orderedFirewallPolicyIds"`
+		}
+		json.Unmarshal(body, &req)
+		ordering := req.OrderedFirewallPolicyIDs
+		ids := append(append([]string{}, ordering.BeforeSystemDefined...), ordering.AfterSystemDefined...)
+		seen := map[string]bool{}
+		for _, id := range ids {
+			if !pairIDs[id] || seen[id] {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "invalid policy id " + id})
+				return
+			}
+			seen[id] = true
+		}
+		if len(seen) != len(pairIDs) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "ordering must list every policy of the zone pair"})
+			return
+		}
+		m.fwOrdering[key] = ordering
+		json.NewEncoder(w).Encode(map[string]interface{}{"orderedFirewallPolicyIds": ordering})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
 func (m *mockUnifiAPI) handleFWPolicy(w http.ResponseWriter, r *http.Request, siteID, policyID string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
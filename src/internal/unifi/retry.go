@@ -0,0 +1,110 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how the client retries requests that failed with a
+// transient controller error. UniFi gateways answer 502/503 while the Network
+// Application restarts or provisions, and 429 when rate limited.
+type RetryPolicy struct {
+	MaxRetries int           // retries after the first attempt; 0 disables retrying
+	MinWait    time.Duration // backoff before the first retry
+	MaxWait    time.Duration // upper bound for any single wait, including Retry-After
+}
+
+// DefaultRetryPolicy returns the policy used by new clients.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 4,
+		MinWait:    500 * time.Millisecond,
+		MaxWait:    30 * time.Second,
+	}
+}
+
+// retryableStatus reports whether a response status indicates a transient
+// condition worth retrying.
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// shouldRetry decides whether a failed request may be sent again. GET, PUT and
+// DELETE are idempotent and retry on transient statuses and transport errors.
+// POST could create a duplicate if the first attempt reached the controller, so
+// it only retries when the connection was refused outright. Callers must not
+// retry once the request's own context is done.
+func shouldRetry(method string, err error) bool {
+	if method == http.MethodPost {
+		return errors.Is(err, syscall.ECONNREFUSED)
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatus(apiErr.StatusCode)
+	}
+
+	// Transport-level failure (connection reset, refused, EOF, ...).
+	return true
+}
+
+// backoff returns how long to wait before retry number attempt (0-based).
+// A server-provided Retry-After takes precedence; otherwise the wait grows
+// exponentially from MinWait with jitter, so concurrent resources don't retry
+// in lockstep. Both are capped at MaxWait.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, p.MaxWait)
+	}
+
+	wait := p.MinWait
+	for i := 0; i < attempt && wait < p.MaxWait; i++ {
+		wait *= 2
+	}
+	wait = min(wait, p.MaxWait)
+	if wait <= 0 {
+		return 0
+	}
+
+	// Equal jitter: somewhere between half and the full backoff.
+	half := wait / 2
+	return half + rand.N(wait-half+1)
+}
+
+// parseRetryAfter parses a Retry-After header given either as delay seconds
+// or as an HTTP date. It returns 0 when the header is absent or invalid.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,109 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+)
+
+// siteContextKey carries the Site that site-specific calls should go to.
+type siteContextKey struct{}
+
+// WithSite returns a copy of ctx in which site-specific calls go to site
+// rather than the client's default site (SiteID and SiteReference).
+func WithSite(ctx context.Context, site Site) context.Context {
+	return context.WithValue(ctx, siteContextKey{}, site)
+}
+
+// siteID returns the ID of the site ctx is scoped to, or the default site.
+func (c *Client) siteID(ctx context.Context) string {
+	if site, ok := ctx.Value(siteContextKey{}).(Site); ok && site.ID != "" {
+		return site.ID
+	}
+	return c.SiteID
+}
+
+// ContextSiteID returns the ID of the site ctx is scoped to, for APIs that
+// take the site as an argument.
+func (c *Client) ContextSiteID(ctx context.Context) string {
+	return c.siteID(ctx)
+}
+
+// siteReference returns the internal reference ("default") of the site ctx is
+// scoped to, or of the default site. Legacy REST paths use it instead of the
+// site ID.
+func (c *Client) siteReference(ctx context.Context) string {
+	if site, ok := ctx.Value(siteContextKey{}).(Site); ok && site.InternalReference != "" {
+		return site.InternalReference
+	}
+	return c.SiteReference
+}
+
+// DiscoverSite resolves a site input (UUID, name, internal reference, or "auto")
+// to a concrete Site from the list of available sites.
+func DiscoverSite(sites []Site, siteInput string) (Site, error) {
+	if siteInput == "auto" {
+		if len(sites) == 1 {
+			return sites[0], nil
+		} else if len(sites) == 0 {
+			return Site{}, fmt.Errorf("auto-discovery failed: no sites were found")
+		}
+		return Site{}, fmt.Errorf("auto-discovery failed: multiple sites exist, please specify site name or UUID")
+	}
+
+	for _, s := range sites {
+		if s.ID == siteInput || s.Name == siteInput || s.InternalReference == siteInput {
+			return s, nil
+		}
+	}
+
+	return Site{}, fmt.Errorf("could not find site matching: %s", siteInput)
+}
+
+// ResolveSite resolves a site input the way DiscoverSite does. Results are
+// cached per input for the life of the client, since sites are rarely
+// created or renamed during a run.
+func (c *Client) ResolveSite(ctx context.Context, siteInput string) (Site, error) {
+	c.mu.Lock()
+	site, ok := c.sites[siteInput]
+	c.mu.Unlock()
+	if ok {
+		return site, nil
+	}
+
+	v, err := c.doShared(ctx, "site:"+siteInput, func() (interface{}, error) {
+		sites, err := c.ListSites(ctx)
+		if err != nil {
+			return nil, err
+		}
+		site, err := DiscoverSite(sites, siteInput)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		if c.sites == nil {
+			c.sites = make(map[string]Site)
+		}
+		c.sites[siteInput] = site
+		c.mu.Unlock()
+
+		return site, nil
+	})
+	if err != nil {
+		return Site{}, err
+	}
+	return v.(Site), nil
+}
+
+// ForSite returns ctx scoped to the site siteInput resolves to. An empty
+// input, or the default site's ID, leaves ctx on the default site.
+func (c *Client) ForSite(ctx context.Context, siteInput string) (context.Context, error) {
+	if siteInput == "" || siteInput == c.SiteID {
+		return ctx, nil
+	}
+	site, err := c.ResolveSite(ctx, siteInput)
+	if err != nil {
+		return ctx, err
+	}
+	return WithSite(ctx, site), nil
+}
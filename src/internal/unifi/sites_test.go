@@ -0,0 +1,194 @@
+package unifi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDiscoverSite_Auto_SingleSite(t *testing.T) {
+	sites := []Site{
+		{ID: "site-1", Name: "Default", InternalReference: "default"},
+	}
+
+	site, err := DiscoverSite(sites, "auto")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if site.ID != "site-1" {
+		t.Errorf("expected ID 'site-1', got %q", site.ID)
+	}
+	if site.InternalReference != "default" {
+		t.Errorf("expected InternalReference 'default', got %q", site.InternalReference)
+	}
+}
+
+func TestDiscoverSite_Auto_NoSites(t *testing.T) {
+	sites := []Site{}
+
+	_, err := DiscoverSite(sites, "auto")
+	if err == nil {
+		t.Fatal("expected error for no sites")
+	}
+	if got := err.Error(); !contains(got, "no sites") {
+		t.Errorf("expected 'no sites' in error, got: %s", got)
+	}
+}
+
+func TestDiscoverSite_Auto_MultipleSites(t *testing.T) {
+	sites := []Site{
+		{ID: "site-1", Name: "Site 1"},
+		{ID: "site-2", Name: "Site 2"},
+	}
+
+	_, err := DiscoverSite(sites, "auto")
+	if err == nil {
+		t.Fatal("expected error for multiple sites")
+	}
+	if got := err.Error(); !contains(got, "multiple sites") {
+		t.Errorf("expected 'multiple sites' in error, got: %s", got)
+	}
+}
+
+func TestDiscoverSite_ByID(t *testing.T) {
+	sites := []Site{
+		{ID: "site-abc", Name: "Production", InternalReference: "prod"},
+		{ID: "site-xyz", Name: "Staging", InternalReference: "staging"},
+	}
+
+	site, err := DiscoverSite(sites, "site-xyz")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if site.ID != "site-xyz" {
+		t.Errorf("expected 'site-xyz', got %q", site.ID)
+	}
+}
+
+func TestDiscoverSite_ByName(t *testing.T) {
+	sites := []Site{
+		{ID: "site-abc", Name: "Production", InternalReference: "prod"},
+	}
+
+	site, err := DiscoverSite(sites, "Production")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if site.ID != "site-abc" {
+		t.Errorf("expected 'site-abc', got %q", site.ID)
+	}
+}
+
+func TestDiscoverSite_ByInternalReference(t *testing.T) {
+	sites := []Site{
+		{ID: "site-abc", Name: "Production", InternalReference: "prod"},
+	}
+
+	site, err := DiscoverSite(sites, "prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if site.ID != "site-abc" {
+		t.Errorf("expected 'site-abc', got %q", site.ID)
+	}
+	if site.InternalReference != "prod" {
+		t.Errorf("expected InternalReference 'prod', got %q", site.InternalReference)
+	}
+}
+
+func TestDiscoverSite_NotFound(t *testing.T) {
+	sites := []Site{
+		{ID: "site-1", Name: "Default", InternalReference: "default"},
+	}
+
+	_, err := DiscoverSite(sites, "nonexistent")
+	if err == nil {
+		t.Fatal("expected error for not found")
+	}
+	if got := err.Error(); !contains(got, "nonexistent") {
+		t.Errorf("expected 'nonexistent' in error, got: %s", got)
+	}
+}
+
+// addBranchSite adds a second site with its own network and client.
+func addBranchSite(mock *mockUnifiAPI) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	mock.sites = append(mock.sites, Site{ID: "site-2", Name: "Branch", InternalReference: "branch"})
+	mock.networks["site-2"] = []Network{{ID: "net-b1", Name: "Branch LAN", VlanID: 10}}
+	mock.clients["site-2"] = []ClientDevice{{ID: "client-b1", MAC: "02:00:00:00:00:01", Name: "printer"}}
+}
+
+func TestForSite_ScopesRequests(t *testing.T) {
+	srv, mock := newMockServer(t)
+	addBranchSite(mock)
+	client := newClientWithSiteRef(srv.URL)
+
+	ctx, err := client.ForSite(context.Background(), "Branch")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.GetNetwork(ctx, "net-b1"); err != nil {
+		t.Errorf("expected the branch network to be found, got %v", err)
+	}
+	if mock.GetCallCount("GET", "/v1/sites/site-2/networks") != 1 {
+		t.Error("expected the request to go to site-2")
+	}
+
+	clients, err := client.ListClients(ctx, "site-2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clients) != 1 || clients[0].ID != "client-b1" {
+		t.Errorf("expected the branch client, got %+v", clients)
+	}
+	if mock.GetCallCount("GET", "/api/s/branch/rest/user") != 1 {
+		t.Error("expected the legacy request to use the branch site reference")
+	}
+
+	// The client itself stays on its default site.
+	if _, err := client.GetNetwork(context.Background(), "net-1"); err != nil {
+		t.Errorf("expected the default site network to be found, got %v", err)
+	}
+}
+
+func TestForSite_DefaultSite(t *testing.T) {
+	srv, mock := newMockServer(t)
+	client := newClientWithSiteRef(srv.URL)
+
+	for _, input := range []string{"", "site-1"} {
+		ctx, err := client.ForSite(context.Background(), input)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", input, err)
+		}
+		if got := client.ContextSiteID(ctx); got != "site-1" {
+			t.Errorf("%q: expected site-1, got %q", input, got)
+		}
+	}
+	if mock.GetCallCount("GET", "/v1/sites") != 0 {
+		t.Error("expected the default site to need no lookup")
+	}
+}
+
+func TestResolveSite_Cached(t *testing.T) {
+	srv, mock := newMockServer(t)
+	addBranchSite(mock)
+	client := newClientWithSiteRef(srv.URL)
+
+	for i := 0; i < 3; i++ {
+		site, err := client.ResolveSite(context.Background(), "branch")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if site.ID != "site-2" {
+			t.Errorf("expected site-2, got %q", site.ID)
+		}
+	}
+	if n := mock.GetCallCount("GET", "/v1/sites"); n != 1 {
+		t.Errorf("expected sites to be listed once, got %d", n)
+	}
+
+	if _, err := client.ForSite(context.Background(), "Elsewhere"); err == nil || !contains(err.Error(), "Elsewhere") {
+		t.Errorf("expected an error naming the unknown site, got %v", err)
+	}
+}